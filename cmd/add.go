@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/bmuschko/letsgopher/template/archive"
+	"github.com/bmuschko/letsgopher/template/download"
+	"github.com/bmuschko/letsgopher/template/environment"
+	"github.com/bmuschko/letsgopher/template/generate"
+	"github.com/bmuschko/letsgopher/template/gomod"
+	"github.com/bmuschko/letsgopher/template/hook"
+	"github.com/bmuschko/letsgopher/template/prompt"
+	"github.com/spf13/cobra"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+type componentAddCmd struct {
+	projectCreateCmd
+}
+
+func newAddCmd(out io.Writer) *cobra.Command {
+	add := &componentAddCmd{projectCreateCmd: projectCreateCmd{out: out}}
+	var conflicts string
+
+	cmd := &cobra.Command{
+		Use:   "add [args]",
+		Short: "add a component generated from a template to an existing Go module",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 || len(args) > 3 {
+				return errors.New("this command needs 2 or 3 arguments: the template name, the template version, optionally the target directory")
+			}
+
+			policy, err := archive.ParseConflictPolicy(conflicts)
+			if err != nil {
+				return err
+			}
+
+			add.templateName = args[0]
+			add.templateVersion = args[1]
+			add.targetDir = "."
+			if len(args) == 3 {
+				add.targetDir = args[2]
+			}
+			add.home = environment.Settings.Home
+			add.archiver = &archive.ZIPArchiver{Processor: &archive.TemplateProcessor{}, Conflicts: policy}
+			add.downloader = &download.TemplateDownloader{Home: environment.Settings.Home, Getter: download.NewHTTPGetter()}
+			add.prompter = &prompt.InteractivePrompter{}
+			add.lookupEnv = os.LookupEnv
+			add.confirmer = &prompt.InteractivePrompter{}
+			add.executor = &hook.CommandExecutor{Out: out, Err: os.Stderr}
+			return add.run()
+		},
+	}
+
+	add.addFlags(cmd, "adding the component")
+	cmd.PersistentFlags().StringVar(&conflicts, "conflict", string(archive.FailOnConflicts), "treatment of files that already exist: fail, skip or overwrite")
+	return cmd
+}
+
+func (c *componentAddCmd) run() error {
+	implicit, err := moduleParams(c.targetDir)
+	if err != nil {
+		return err
+	}
+
+	template, err := determineTemplate(&c.projectCreateCmd)
+	if err != nil {
+		return err
+	}
+	archives, templateManifest, err := loadTemplateChain(c.home, template, c.archiver)
+	if err != nil {
+		return err
+	}
+	if templateManifest.GoModule {
+		return fmt.Errorf("template %q is marked as Go module and can only be used to create a new project", c.templateName)
+	}
+
+	resolver, err := c.newResolver()
+	if err != nil {
+		return err
+	}
+	resolver.Implicit = implicit
+	if c.explainParams {
+		return explainParameterValues(c.out, resolver, templateManifest.Parameters)
+	}
+	r, err := resolver.Resolve(templateManifest.Parameters)
+	if err != nil {
+		return err
+	}
+
+	// Conflicts are checked before running the hooks, so an add aborted by existing files has no side effects.
+	err = c.archiver.CheckConflicts(archives, c.targetDir, r)
+	if err != nil {
+		return conflictError(err)
+	}
+	err = c.runPreGenerateHooks(templateManifest.Hooks.PreGenerate, r)
+	if err != nil {
+		return err
+	}
+	postProcessors, err := generate.PostProcessors(c.archiver, archives, templateManifest, r, c.format)
+	if err != nil {
+		return err
+	}
+	err = c.archiver.ExtractLayers(archives, c.targetDir, r, postProcessors...)
+	if err == nil {
+		// Components aren't recorded in a provenance file, hence the references to the generated dependencies aren't kept either.
+		_, err = c.generateDependencies(template.Name, templateManifest.Dependencies, r, resolver)
+	}
+	if err != nil {
+		return conflictError(err)
+	}
+	fmt.Fprintf(c.out, "added component at %q\n", c.targetDir)
+	return c.runPostGenerateHooks(templateManifest.Hooks.PostGenerate, r)
+}
+
+// conflictError explains how to resolve a conflict with existing files. Other errors are returned unchanged.
+func conflictError(err error) error {
+	if conflictErr, ok := err.(*archive.ConflictError); ok {
+		return fmt.Errorf("%s\nuse --conflict=skip to keep or --conflict=overwrite to replace existing files", conflictErr)
+	}
+	return err
+}
+
+// moduleParams determines the implicit parameters describing the Go module enclosing the target directory.
+// The target directory needs to be a subdirectory of the module root.
+func moduleParams(targetDir string) (map[string]interface{}, error) {
+	goModFile, err := gomod.Find(closestExistingDir(targetDir))
+	if err != nil {
+		return nil, fmt.Errorf("components can only be added to an existing Go module: %s", err)
+	}
+	b, err := ioutil.ReadFile(goModFile)
+	if err != nil {
+		return nil, err
+	}
+	f, err := gomod.Parse(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", goModFile, err)
+	}
+
+	moduleRoot := filepath.Dir(goModFile)
+	absTargetDir, err := filepath.Abs(targetDir)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(moduleRoot, absTargetDir)
+	if err != nil {
+		return nil, err
+	}
+	if rel == "." {
+		return nil, fmt.Errorf("components need to be added to a subdirectory of the module root %q", moduleRoot)
+	}
+
+	return map[string]interface{}{
+		generate.ModulePathParam:      f.Module,
+		generate.ModuleGoVersionParam: f.Go,
+		generate.ImportPathParam:      path.Join(f.Module, filepath.ToSlash(rel)),
+	}, nil
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"bytes"
+	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/template/archive"
+	"github.com/bmuschko/letsgopher/template/storage"
+	"github.com/bmuschko/letsgopher/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"path/filepath"
+	"testing"
+)
+
+func TestAddComponentOutsideOfGoModule(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	b := bytes.NewBuffer(nil)
+	componentAdd := &componentAddCmd{projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       filepath.Join(tmpDir, "handler"),
+		out:             b,
+	}}
+	err := componentAdd.run()
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "components can only be added to an existing Go module: could not locate go.mod file")
+}
+
+func TestAddComponentToModuleRoot(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	testhelper.WriteFile(t, filepath.Join(tmpDir, "go.mod"), "module github.com/acme/hello\n\ngo 1.13\n", 0644)
+	b := bytes.NewBuffer(nil)
+	componentAdd := &componentAddCmd{projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       tmpDir,
+		out:             b,
+	}}
+	err := componentAdd.run()
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "components need to be added to a subdirectory of the module root")
+}
+
+func TestAddComponentProvidesModuleParams(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	testhelper.WriteFile(t, filepath.Join(tmpDir, "go.mod"), "module github.com/acme/hello\n\ngo 1.13\n", 0644)
+	targetDir := filepath.Join(tmpDir, "internal", "handler")
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	componentAdd := &componentAddCmd{projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		params:          []string{"name=orders"},
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(`version: "1.0.0"
+parameters:
+  - name: "name"
+    type: "string"`), nil)
+	replacements := map[string]interface{}{
+		"name":            "orders",
+		"modulePath":      "github.com/acme/hello",
+		"moduleGoVersion": "1.13",
+		"importPath":      "github.com/acme/hello/internal/handler",
+	}
+	aM.On("CheckConflicts", []string{archiveZip}, targetDir, replacements).Return(nil)
+	aM.On("ExtractLayers", []string{archiveZip}, targetDir, replacements, noPostProcessors).Return(nil)
+	err := componentAdd.run()
+
+	aM.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, "added component at \""+targetDir+"\"\n", b.String())
+}
+
+func TestAddComponentWithConflictingFiles(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	testhelper.WriteFile(t, filepath.Join(tmpDir, "go.mod"), "module github.com/acme/hello\n\ngo 1.13\n", 0644)
+	targetDir := filepath.Join(tmpDir, "handler")
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	eM := new(ExecutorMock)
+	componentAdd := &componentAddCmd{projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		trustHooks:      true,
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+		executor:        eM,
+	}}
+	conflict := filepath.Join(targetDir, "handler.go")
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(`version: "1.0.0"
+hooks:
+  preGenerate:
+    - command: ["go", "generate", "./..."]`), nil)
+	aM.On("CheckConflicts", []string{archiveZip}, targetDir, map[string]interface{}{
+		"modulePath":      "github.com/acme/hello",
+		"moduleGoVersion": "1.13",
+		"importPath":      "github.com/acme/hello/handler",
+	}).Return(&archive.ConflictError{Paths: []string{conflict}})
+	err := componentAdd.run()
+
+	aM.AssertExpectations(t)
+	aM.AssertNotCalled(t, "ExtractLayers", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	eM.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+	assert.NotNil(t, err)
+	assert.Equal(t, "the following files already exist:\n  "+conflict+"\nuse --conflict=skip to keep or --conflict=overwrite to replace existing files", err.Error())
+}
+
+func TestAddComponentFromGoModuleTemplate(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	testhelper.WriteFile(t, filepath.Join(tmpDir, "go.mod"), "module github.com/acme/hello\n", 0644)
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	aM := new(ArchiverMock)
+	componentAdd := &componentAddCmd{projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       filepath.Join(tmpDir, "handler"),
+		out:             bytes.NewBuffer(nil),
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(`version: "1.0.0"
+goModule: true
+parameters:
+  - name: "module"
+    type: "modulePath"`), nil)
+	err := componentAdd.run()
+
+	aM.AssertExpectations(t)
+	assert.NotNil(t, err)
+	assert.Equal(t, "template \"hello-world\" is marked as Go module and can only be used to create a new project", err.Error())
+}
+
+func TestAddCommandRegistersFlagsOfCreateCommand(t *testing.T) {
+	create := newCreateCmd(bytes.NewBuffer(nil))
+	add := newAddCmd(bytes.NewBuffer(nil))
+
+	for _, name := range []string{"param", "answers", "explain-params", "no-hooks", "trust-hooks", "format"} {
+		assert.NotNil(t, create.PersistentFlags().Lookup(name), name)
+		assert.NotNil(t, add.PersistentFlags().Lookup(name), name)
+	}
+	assert.Equal(t, "print the source each parameter value is resolved from without adding the component", add.PersistentFlags().Lookup("explain-params").Usage)
+	assert.NotNil(t, add.PersistentFlags().Lookup("conflict"))
+}
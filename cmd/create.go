@@ -4,11 +4,18 @@ import (
 	"fmt"
 	"github.com/bmuschko/letsgopher/template/archive"
 	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/bmuschko/letsgopher/template/download"
 	"github.com/bmuschko/letsgopher/template/environment"
+	"github.com/bmuschko/letsgopher/template/generate"
+	"github.com/bmuschko/letsgopher/template/hook"
+	"github.com/bmuschko/letsgopher/template/param"
 	"github.com/bmuschko/letsgopher/template/prompt"
 	"github.com/bmuschko/letsgopher/template/storage"
+	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 )
 
@@ -19,10 +26,19 @@ type projectCreateCmd struct {
 	templateVersion string
 	targetDir       string
 	params          []string
+	answersFile     string
+	explainParams   bool
+	noHooks         bool
+	trustHooks      bool
+	format          bool
 	out             io.Writer
 	home            storage.Home
 	archiver        archive.Archiver
+	downloader      download.Downloader
 	prompter        prompt.Prompter
+	lookupEnv       func(key string) (string, bool)
+	confirmer       prompt.Confirmer
+	executor        hook.Executor
 }
 
 func newCreateCmd(out io.Writer) *cobra.Command {
@@ -41,111 +57,304 @@ func newCreateCmd(out io.Writer) *cobra.Command {
 			create.targetDir = args[2]
 			create.home = environment.Settings.Home
 			create.archiver = &archive.ZIPArchiver{Processor: &archive.TemplateProcessor{}}
+			create.downloader = &download.TemplateDownloader{Home: environment.Settings.Home, Getter: download.NewHTTPGetter()}
 			create.prompter = &prompt.InteractivePrompter{}
+			create.lookupEnv = os.LookupEnv
+			create.confirmer = &prompt.InteractivePrompter{}
+			create.executor = &hook.CommandExecutor{Out: out, Err: os.Stderr}
 			return create.run()
 		},
 	}
 
-	cmd.PersistentFlags().StringSliceVar(&create.params, "param", []string{}, "parameter defined as key/value pair separated by = character")
+	create.addFlags(cmd, "creating the project")
 	return cmd
 }
 
+// addFlags registers the flags shared by the commands generating files from a template.
+// The action describes what the command does, e.g. "creating the project".
+func (c *projectCreateCmd) addFlags(cmd *cobra.Command, action string) {
+	cmd.PersistentFlags().StringSliceVar(&c.params, "param", []string{}, "parameter defined as key/value pair separated by = character, repeat the key for list and map values")
+	cmd.PersistentFlags().StringVar(&c.answersFile, "answers", "", "YAML file providing parameter values by parameter name")
+	cmd.PersistentFlags().BoolVar(&c.explainParams, "explain-params", false, "print the source each parameter value is resolved from without "+action)
+	cmd.PersistentFlags().BoolVar(&c.noHooks, "no-hooks", false, "do not run the commands of the pre-generation and post-generation hooks of the template, checks are still evaluated")
+	cmd.PersistentFlags().BoolVar(&c.trustHooks, "trust-hooks", false, "run the hooks of the template without asking for confirmation")
+	cmd.PersistentFlags().BoolVar(&c.format, "format", false, "format generated Go source files with gofmt")
+}
+
 func (c *projectCreateCmd) run() error {
-	templateZIP, err := determineTemplateZIP(c)
+	template, err := determineTemplate(c)
+	if err != nil {
+		return err
+	}
+	archives, templateManifest, err := loadTemplateChain(c.home, template, c.archiver)
 	if err != nil {
 		return err
 	}
 
-	templateManifest, err := loadTemplateManifest(templateZIP, c.archiver)
+	resolver, err := c.newResolver()
+	if err != nil {
+		return err
+	}
+	if c.explainParams {
+		return explainParameterValues(c.out, resolver, templateManifest.Parameters)
+	}
+	r, err := resolver.Resolve(templateManifest.Parameters)
 	if err != nil {
 		return err
 	}
 
-	userDefinedParams, err := mapUserDefinedParams(c.params)
+	err = c.runPreGenerateHooks(templateManifest.Hooks.PreGenerate, r)
+	if err != nil {
+		return err
+	}
+	postProcessors, err := generate.PostProcessors(c.archiver, archives, templateManifest, r, c.format)
 	if err != nil {
 		return err
 	}
-	r, err := requestParameterValues(userDefinedParams, templateManifest.Parameters, c.prompter)
+	err = c.archiver.ExtractLayers(archives, c.targetDir, r, postProcessors...)
 	if err != nil {
 		return err
 	}
+	dependencies, err := c.generateDependencies(template.Name, templateManifest.Dependencies, r, resolver)
+	if err != nil {
+		return err
+	}
+	err = writeProvenanceFile(template, c.targetDir, templateManifest.Parameters, r, dependencies, c.format)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "created project at %q\n", c.targetDir)
+	return c.runPostGenerateHooks(templateManifest.Hooks.PostGenerate, r)
+}
 
-	err = c.archiver.Extract(templateZIP, c.targetDir, r)
+// runPreGenerateHooks evaluates the checks of the pre-generation hooks and runs their commands.
+// Checks don't have side effects and are evaluated even if hooks are skipped or not confirmed.
+func (c *projectCreateCmd) runPreGenerateHooks(hooks []*config.Hook, replacements map[string]interface{}) error {
+	err := hook.Verify(hooks, replacements)
 	if err != nil {
+		return err
+	}
+	if c.noHooks || len(hooks) == 0 {
 		return nil
 	}
-	fmt.Fprintf(c.out, "created project at %q\n", c.targetDir)
-	return err
+	commands, err := hook.Prepare(hooks, closestExistingDir(c.targetDir), replacements)
+	if err != nil {
+		return err
+	}
+	ok, err := c.confirmHooks("pre-generation", commands)
+	if err != nil || !ok {
+		return err
+	}
+	return hook.Run(commands, c.executor, c.out)
 }
 
-func determineTemplateZIP(c *projectCreateCmd) (string, error) {
-	f, err := config.LoadTemplatesFile(c.home.TemplatesFile())
+func (c *projectCreateCmd) runPostGenerateHooks(hooks []*config.Hook, replacements map[string]interface{}) error {
+	if c.noHooks || len(hooks) == 0 {
+		return nil
+	}
+	commands, err := hook.Prepare(hooks, c.targetDir, replacements)
 	if err != nil {
-		return "", err
+		return err
+	}
+	ok, err := c.confirmHooks("post-generation", commands)
+	if err != nil || !ok {
+		return err
 	}
+	return hook.Run(commands, c.executor, c.out)
+}
 
-	template := f.Get(c.templateName, c.templateVersion)
-	if template == nil {
-		return "", fmt.Errorf("template with name %q and version %q hasn't been installed", c.templateName, c.templateVersion)
+func (c *projectCreateCmd) confirmHooks(stage string, commands []*hook.Command) (bool, error) {
+	if len(commands) == 0 {
+		return false, nil
+	}
+	if c.trustHooks {
+		return true, nil
 	}
-	return template.ArchivePath, nil
+	fmt.Fprintf(c.out, "the template defines the following %s hooks:\n", stage)
+	for _, command := range commands {
+		fmt.Fprintf(c.out, "  %s (in %s)\n", command, command.Dir)
+	}
+	ok, err := c.confirmer.Confirm(fmt.Sprintf("Do you want to run the %s hooks?", stage))
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		fmt.Fprintf(c.out, "skipped %s hooks\n", stage)
+	}
+	return ok, nil
 }
 
-func loadTemplateManifest(templateZIP string, archiver archive.Archiver) (*config.ManifestFile, error) {
-	tb, err := archiver.LoadManifestFile(templateZIP)
+// closestExistingDir returns the directory itself if it exists or its closest existing parent directory.
+func closestExistingDir(dir string) string {
+	d, err := filepath.Abs(dir)
 	if err != nil {
-		return nil, err
+		return dir
 	}
-	m, err := config.LoadManifestData(tb)
+	for {
+		if fi, err := os.Stat(d); err == nil && fi.IsDir() {
+			return d
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return d
+		}
+		d = parent
+	}
+}
+
+func determineTemplate(c *projectCreateCmd) (*config.Template, error) {
+	f, err := config.LoadTemplatesFile(c.home.TemplatesFile())
 	if err != nil {
 		return nil, err
 	}
-	err = config.ValidateManifest(m)
+
+	return installedTemplate(f, c.templateName, c.templateVersion)
+}
+
+// writeProvenanceFile records the template, dependencies and parameter values a project has been generated from in its root directory.
+// The format option records whether the generated Go source files have been formatted.
+func writeProvenanceFile(template *config.Template, targetDir string, params []*config.Parameter, replacements map[string]interface{}, dependencies []*config.DependencyReference, format bool) error {
+	ref, err := newTemplateReference(template)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	p := config.NewProvenanceFile(ref, params, replacements)
+	p.Dependencies = dependencies
+	p.Format = format
+	return p.WriteFile(filepath.Join(targetDir, config.ProvenanceFileName), 0644)
+}
+
+func newTemplateReference(template *config.Template) (config.TemplateReference, error) {
+	digest, err := archive.Digest(template.ArchivePath)
+	if err != nil {
+		return config.TemplateReference{}, err
+	}
+	return config.TemplateReference{
+		Name:      template.Name,
+		Version:   template.Version,
+		Digest:    digest,
+		SourceURL: template.SourceURL,
+	}, nil
+}
+
+// loadTemplateChain loads the manifest of a template and of all templates it extends.
+// The archives are ordered from the root template to the template itself so that files of extending templates take precedence.
+func loadTemplateChain(home storage.Home, template *config.Template, archiver archive.Archiver) ([]string, *config.ManifestFile, error) {
+	var f *config.TemplatesFile
+	archives := []string{}
+	manifests := []*config.ManifestFile{}
+	chain := []string{}
+	for current := template; ; {
+		id := fmt.Sprintf("%s %s", current.Name, current.Version)
+		for _, visited := range chain {
+			if visited == id {
+				return nil, nil, fmt.Errorf("template inheritance cycle detected: %s", strings.Join(append(chain, id), " -> "))
+			}
+		}
+		chain = append(chain, id)
+
+		tb, err := archiver.LoadManifestFile(current.ArchivePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		m, err := config.LoadManifestData(tb)
+		if err != nil {
+			return nil, nil, err
+		}
+		err = checkCompatibility(current, m)
+		if err != nil {
+			return nil, nil, err
+		}
+		archives = append([]string{current.ArchivePath}, archives...)
+		manifests = append(manifests, m)
+		if m.Extends == nil {
+			break
+		}
+		err = m.Extends.Validate()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if f == nil {
+			f, err = config.LoadTemplatesFile(home.TemplatesFile())
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		parent := f.Get(m.Extends.Name, m.Extends.Version)
+		if parent == nil {
+			return nil, nil, fmt.Errorf("template %q extends template %q with version %q which hasn't been installed", current.Name, m.Extends.Name, m.Extends.Version)
+		}
+		current = parent
+	}
+
+	merged := manifests[len(manifests)-1]
+	for i := len(manifests) - 2; i >= 0; i-- {
+		merged = manifests[i].Inherit(merged)
+	}
+	err := config.ValidateManifest(merged)
+	if err != nil {
+		return nil, nil, err
 	}
-	return m, nil
+	return archives, merged, nil
 }
 
-func mapUserDefinedParams(params []string) (map[string]string, error) {
-	userDefinedParams := make(map[string]string)
+// checkCompatibility verifies that a template supports the running letsgopher version.
+func checkCompatibility(template *config.Template, m *config.ManifestFile) error {
+	err := m.CheckLetsgopherVersion(version)
+	if err != nil {
+		return fmt.Errorf("template %q with version %q is incompatible: %s", template.Name, template.Version, err)
+	}
+	return nil
+}
+
+func mapUserDefinedParams(params []string) (map[string][]string, error) {
+	userDefinedParams := make(map[string][]string)
 	for _, p := range params {
 		if !strings.Contains(p, keyValueSeparator) {
 			return nil, fmt.Errorf("user-defined parameter %q does not separate key and value by %s character", p, keyValueSeparator)
 		}
-		s := strings.Split(p, keyValueSeparator)
-		userDefinedParams[s[0]] = s[1]
+		s := strings.SplitN(p, keyValueSeparator, 2)
+		userDefinedParams[s[0]] = append(userDefinedParams[s[0]], s[1])
 	}
 	return userDefinedParams, nil
 }
 
-func requestParameterValues(userDefinedParams map[string]string, manifestParams []*config.Parameter, prompter prompt.Prompter) (map[string]interface{}, error) {
-	replacements := make(map[string]interface{})
-
-	for _, p := range manifestParams {
-		if value, exist := userDefinedParams[p.Name]; exist {
-			if p.Enum != nil && !contains(p.Enum, value) {
-				return nil, fmt.Errorf("provided value '%s' is not defined in enum [%s]",
-					value, strings.Join(p.Enum, ", "))
-			}
-			replacements[p.Name] = value
-			continue
-		}
+func (c *projectCreateCmd) newResolver() (*param.Resolver, error) {
+	return newResolver(c.params, c.answersFile, c.lookupEnv, c.prompter)
+}
 
-		err := prompter.Prompt(p, replacements)
+func newResolver(params []string, answersFile string, lookupEnv func(key string) (string, bool), prompter prompt.Prompter) (*param.Resolver, error) {
+	userDefinedParams, err := mapUserDefinedParams(params)
+	if err != nil {
+		return nil, err
+	}
+	answers := make(map[string]string)
+	if answersFile != "" {
+		answers, err = config.LoadAnswersFile(answersFile)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to load answers file %q: %s", answersFile, err)
 		}
 	}
-
-	return replacements, nil
+	return &param.Resolver{
+		Params:    userDefinedParams,
+		Answers:   answers,
+		LookupEnv: lookupEnv,
+		Prompter:  prompter,
+	}, nil
 }
 
-func contains(s []string, e string) bool {
-	for _, a := range s {
-		if a == e {
-			return true
-		}
+func explainParameterValues(out io.Writer, resolver *param.Resolver, manifestParams []*config.Parameter) error {
+	sources := []string{}
+	for _, s := range param.Precedence {
+		sources = append(sources, string(s))
+	}
+	fmt.Fprintf(out, "parameter values are resolved in the order: %s\n", strings.Join(sources, ", "))
+	table := uitable.New()
+	table.AddRow("NAME", "SOURCE", "VALUE")
+	for _, res := range resolver.Explain(manifestParams) {
+		table.AddRow(res.Parameter.Name, res.Describe(), res.Value)
 	}
-	return false
+	fmt.Fprintln(out, table)
+	return nil
 }
@@ -2,11 +2,17 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/template/archive"
+	"github.com/bmuschko/letsgopher/template/config"
 	"github.com/bmuschko/letsgopher/template/storage"
 	"github.com/bmuschko/letsgopher/testhelper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -33,46 +39,36 @@ templates: []`, 0644)
 
 func TestCreateProjectWithRegisteredTemplate(t *testing.T) {
 	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
 	defer filet.CleanUp(t)
 
-	f := storage.Home(tmpHome).TemplatesFile()
-	archiveZip := storage.Home(tmpHome).ArchiveDir() + "/hello-world-1.0.0.zip"
-	testhelper.WriteFile(t, f, fmt.Sprintf(`generated: "2019-03-15T16:31:57.232715-06:00"
-templates:
-- archivePath: %s
-  name: hello-world
-  version: 1.0.0`, archiveZip), 0644)
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
 
 	b := bytes.NewBuffer(nil)
 	aM := new(ArchiverMock)
 	projectCreate := &projectCreateCmd{
 		templateName:    "hello-world",
 		templateVersion: "1.0.0",
-		targetDir:       "/target",
+		targetDir:       targetDir,
 		out:             b,
 		home:            storage.Home(tmpHome),
 		archiver:        aM,
 	}
 	aM.On("LoadManifestFile", archiveZip).Return([]byte("version: \"1.0.0\""), nil)
-	aM.On("Extract", archiveZip, "/target", make(map[string]interface{})).Return(nil)
+	aM.On("ExtractLayers", []string{archiveZip}, targetDir, make(map[string]interface{}), noPostProcessors).Return(nil)
 	err := projectCreate.run()
 
 	aM.AssertExpectations(t)
 	assert.Nil(t, err)
-	assert.Equal(t, "created project at \"/target\"\n", b.String())
+	assert.Equal(t, "created project at \""+targetDir+"\"\n", b.String())
 }
 
 func TestCreateProjectWithRegisteredTemplateAndDefinedParams(t *testing.T) {
 	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
 	defer filet.CleanUp(t)
 
-	f := storage.Home(tmpHome).TemplatesFile()
-	archiveZip := storage.Home(tmpHome).ArchiveDir() + "/hello-world-1.0.0.zip"
-	testhelper.WriteFile(t, f, fmt.Sprintf(`generated: "2019-03-15T16:31:57.232715-06:00"
-templates:
-- archivePath: %s
-  name: hello-world
-  version: 1.0.0`, archiveZip), 0644)
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
 
 	b := bytes.NewBuffer(nil)
 	params := make([]string, 2)
@@ -82,7 +78,7 @@ templates:
 	projectCreate := &projectCreateCmd{
 		templateName:    "hello-world",
 		templateVersion: "1.0.0",
-		targetDir:       "/target",
+		targetDir:       targetDir,
 		params:          params,
 		out:             b,
 		home:            storage.Home(tmpHome),
@@ -96,25 +92,20 @@ parameters:
   - name: "param2"
     prompt: "Please provide a value for parameter 2"
     type: "string"`), nil)
-	aM.On("Extract", archiveZip, "/target", map[string]interface{}{"param1": "hello", "param2": "world"}).Return(nil)
+	aM.On("ExtractLayers", []string{archiveZip}, targetDir, map[string]interface{}{"param1": "hello", "param2": "world"}, noPostProcessors).Return(nil)
 	err := projectCreate.run()
 
 	aM.AssertExpectations(t)
 	assert.Nil(t, err)
-	assert.Equal(t, "created project at \"/target\"\n", b.String())
+	assert.Equal(t, "created project at \""+targetDir+"\"\n", b.String())
 }
 
 func TestCreateProjectWithRegisteredTemplateAndNonMatchingEnumParams(t *testing.T) {
 	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
 	defer filet.CleanUp(t)
 
-	f := storage.Home(tmpHome).TemplatesFile()
-	archiveZip := storage.Home(tmpHome).ArchiveDir() + "/hello-world-1.0.0.zip"
-	testhelper.WriteFile(t, f, fmt.Sprintf(`generated: "2019-03-15T16:31:57.232715-06:00"
-templates:
-- archivePath: %s
-  name: hello-world
-  version: 1.0.0`, archiveZip), 0644)
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
 
 	b := bytes.NewBuffer(nil)
 	params := make([]string, 2)
@@ -124,7 +115,7 @@ templates:
 	projectCreate := &projectCreateCmd{
 		templateName:    "hello-world",
 		templateVersion: "1.0.0",
-		targetDir:       "/target",
+		targetDir:       targetDir,
 		params:          params,
 		out:             b,
 		home:            storage.Home(tmpHome),
@@ -145,15 +136,10 @@ parameters:
 
 func TestCreateProjectWithRegisteredTemplateAndMatchingEnumParams(t *testing.T) {
 	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
 	defer filet.CleanUp(t)
 
-	f := storage.Home(tmpHome).TemplatesFile()
-	archiveZip := storage.Home(tmpHome).ArchiveDir() + "/hello-world-1.0.0.zip"
-	testhelper.WriteFile(t, f, fmt.Sprintf(`generated: "2019-03-15T16:31:57.232715-06:00"
-templates:
-- archivePath: %s
-  name: hello-world
-  version: 1.0.0`, archiveZip), 0644)
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
 
 	b := bytes.NewBuffer(nil)
 	params := make([]string, 1)
@@ -162,7 +148,7 @@ templates:
 	projectCreate := &projectCreateCmd{
 		templateName:    "hello-world",
 		templateVersion: "1.0.0",
-		targetDir:       "/target",
+		targetDir:       targetDir,
 		params:          params,
 		out:             b,
 		home:            storage.Home(tmpHome),
@@ -174,25 +160,20 @@ parameters:
     prompt: "Please provide a value for parameter 1"
     type: "string"
     enum: ["a", "hello", "c"]`), nil)
-	aM.On("Extract", archiveZip, "/target", map[string]interface{}{"param1": "hello"}).Return(nil)
+	aM.On("ExtractLayers", []string{archiveZip}, targetDir, map[string]interface{}{"param1": "hello"}, noPostProcessors).Return(nil)
 	err := projectCreate.run()
 
 	aM.AssertExpectations(t)
 	assert.Nil(t, err)
-	assert.Equal(t, "created project at \"/target\"\n", b.String())
+	assert.Equal(t, "created project at \""+targetDir+"\"\n", b.String())
 }
 
 func TestCreateProjectWithMisformedUserDefinedParams(t *testing.T) {
 	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
 	defer filet.CleanUp(t)
 
-	f := storage.Home(tmpHome).TemplatesFile()
-	archiveZip := storage.Home(tmpHome).ArchiveDir() + "/hello-world-1.0.0.zip"
-	testhelper.WriteFile(t, f, fmt.Sprintf(`generated: "2019-03-15T16:31:57.232715-06:00"
-templates:
-- archivePath: %s
-  name: hello-world
-  version: 1.0.0`, archiveZip), 0644)
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
 
 	b := bytes.NewBuffer(nil)
 	params := make([]string, 1)
@@ -201,7 +182,7 @@ templates:
 	projectCreate := &projectCreateCmd{
 		templateName:    "hello-world",
 		templateVersion: "1.0.0",
-		targetDir:       "/target",
+		targetDir:       targetDir,
 		params:          params,
 		out:             b,
 		home:            storage.Home(tmpHome),
@@ -219,3 +200,670 @@ parameters:
 	assert.NotNil(t, err)
 	assert.Equal(t, "user-defined parameter \"param1_hello\" does not separate key and value by = character", err.Error())
 }
+
+func TestCreateProjectWithParamsFromAnswersFileAndEnvironment(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	answersFile := tmpHome + "/answers.yaml"
+	testhelper.WriteFile(t, answersFile, `param1: "from answers"
+param2: "from answers"`, 0644)
+
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		params:          []string{"param1=hello"},
+		answersFile:     answersFile,
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+		lookupEnv: func(key string) (string, bool) {
+			if key == "LETSGOPHER_PARAM_PARAM3" {
+				return "from env", true
+			}
+			return "", false
+		},
+	}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(`version: "1.0.0"
+parameters:
+  - name: "param1"
+    prompt: "Please provide a value for parameter 1"
+    type: "string"
+  - name: "param2"
+    prompt: "Please provide a value for parameter 2"
+    type: "string"
+  - name: "param3"
+    prompt: "Please provide a value for parameter 3"
+    type: "string"`), nil)
+	aM.On("ExtractLayers", []string{archiveZip}, targetDir, map[string]interface{}{"param1": "hello", "param2": "from answers", "param3": "from env"}, noPostProcessors).Return(nil)
+	err := projectCreate.run()
+
+	aM.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, "created project at \""+targetDir+"\"\n", b.String())
+}
+
+func TestCreateProjectExplainParams(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		params:          []string{"param1=hello"},
+		explainParams:   true,
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+		lookupEnv: func(key string) (string, bool) {
+			if key == "PARAM_TWO" {
+				return "world", true
+			}
+			return "", false
+		},
+	}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(`version: "1.0.0"
+parameters:
+  - name: "param1"
+    prompt: "Please provide a value for parameter 1"
+    type: "string"
+  - name: "param2"
+    prompt: "Please provide a value for parameter 2"
+    type: "string"
+    env: "PARAM_TWO"
+  - name: "param3"
+    prompt: "Please provide a value for parameter 3"
+    type: "string"`), nil)
+	err := projectCreate.run()
+
+	aM.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, `parameter values are resolved in the order: --param, answers file, environment, prompt
+NAME  	SOURCE                 	VALUE
+param1	--param                	hello
+param2	environment (PARAM_TWO)	world
+param3	prompt                 	     
+`, b.String())
+}
+
+const hooksManifest = `version: "1.0.0"
+parameters:
+  - name: "param1"
+    prompt: "Please provide a value for parameter 1"
+    type: "string"
+hooks:
+  postGenerate:
+    - command: ["go", "mod", "tidy"]
+    - command: ["make", "{{ .param1 }}"]
+      workingDir: "build"
+    - command: ["git", "init"]
+      when: 'eq .param1 "never"'`
+
+func TestCreateProjectRunsConfirmedPostGenerateHooks(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	cM := new(ConfirmerMock)
+	eM := new(ExecutorMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		params:          []string{"param1=generate"},
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+		confirmer:       cM,
+		executor:        eM,
+	}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(hooksManifest), nil)
+	aM.On("ExtractLayers", []string{archiveZip}, targetDir, map[string]interface{}{"param1": "generate"}, noPostProcessors).Return(nil)
+	cM.On("Confirm", "Do you want to run the post-generation hooks?").Return(true, nil)
+	eM.On("Execute", []string{"go", "mod", "tidy"}, targetDir).Return(nil)
+	eM.On("Execute", []string{"make", "generate"}, filepath.Join(targetDir, "build")).Return(nil)
+	err := projectCreate.run()
+
+	aM.AssertExpectations(t)
+	cM.AssertExpectations(t)
+	eM.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, fmt.Sprintf(`created project at %q
+the template defines the following post-generation hooks:
+  go mod tidy (in %s)
+  make generate (in %s)
+running hook "go mod tidy"
+running hook "make generate"
+`, targetDir, targetDir, filepath.Join(targetDir, "build")), b.String())
+}
+
+func TestCreateProjectSkipsDeclinedPostGenerateHooks(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	cM := new(ConfirmerMock)
+	eM := new(ExecutorMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		params:          []string{"param1=generate"},
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+		confirmer:       cM,
+		executor:        eM,
+	}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(hooksManifest), nil)
+	aM.On("ExtractLayers", []string{archiveZip}, targetDir, map[string]interface{}{"param1": "generate"}, noPostProcessors).Return(nil)
+	cM.On("Confirm", "Do you want to run the post-generation hooks?").Return(false, nil)
+	err := projectCreate.run()
+
+	aM.AssertExpectations(t)
+	cM.AssertExpectations(t)
+	eM.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Contains(t, b.String(), "skipped post-generation hooks\n")
+}
+
+func TestCreateProjectRunsTrustedPostGenerateHooksWithoutConfirmation(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	eM := new(ExecutorMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		params:          []string{"param1=generate"},
+		trustHooks:      true,
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+		executor:        eM,
+	}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(hooksManifest), nil)
+	aM.On("ExtractLayers", []string{archiveZip}, targetDir, map[string]interface{}{"param1": "generate"}, noPostProcessors).Return(nil)
+	eM.On("Execute", []string{"go", "mod", "tidy"}, targetDir).Return(errors.New("exit status 1"))
+	err := projectCreate.run()
+
+	aM.AssertExpectations(t)
+	eM.AssertExpectations(t)
+	assert.NotNil(t, err)
+	assert.Equal(t, "hook \"go mod tidy\" failed: exit status 1", err.Error())
+}
+
+func TestCreateProjectWithoutHooks(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		params:          []string{"param1=generate"},
+		noHooks:         true,
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(hooksManifest), nil)
+	aM.On("ExtractLayers", []string{archiveZip}, targetDir, map[string]interface{}{"param1": "generate"}, noPostProcessors).Return(nil)
+	err := projectCreate.run()
+
+	aM.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, "created project at \""+targetDir+"\"\n", b.String())
+}
+
+func TestCreateProjectWithFormatFlag(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		format:          true,
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte("version: \"1.0.0\""), nil)
+	aM.On("ExtractLayers", []string{archiveZip}, targetDir, make(map[string]interface{}), []archive.PostProcessor{&archive.GoFormatter{}}).Return(nil)
+	err := projectCreate.run()
+
+	aM.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, "created project at \""+targetDir+"\"\n", b.String())
+
+	p, err := config.LoadProvenanceFile(filepath.Join(targetDir, config.ProvenanceFileName))
+	assert.Nil(t, err)
+	assert.True(t, p.Format)
+}
+
+func TestCreateProjectWithFormattingEnabledInManifest(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(`version: "1.0.0"
+formatGoFiles: true`), nil)
+	aM.On("ExtractLayers", []string{archiveZip}, targetDir, make(map[string]interface{}), []archive.PostProcessor{&archive.GoFormatter{}}).Return(nil)
+	err := projectCreate.run()
+
+	aM.AssertExpectations(t)
+	assert.Nil(t, err)
+}
+
+func TestCreateProjectForGoModule(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		params:          []string{"module=github.com/acme/hello"},
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(`version: "1.0.0"
+goModule: true
+parameters:
+  - name: "module"
+    type: "modulePath"`), nil)
+	aM.On("LoadFile", archiveZip, "go.mod").Return([]byte("module github.com/example/placeholder\n"), nil)
+	aM.On("ExtractLayers", []string{archiveZip}, targetDir, map[string]interface{}{"module": "github.com/acme/hello"}, []archive.PostProcessor{&archive.ModuleRewriter{From: "github.com/example/placeholder", To: "github.com/acme/hello"}}).Return(nil)
+	err := projectCreate.run()
+
+	aM.AssertExpectations(t)
+	assert.Nil(t, err)
+}
+
+func TestCreateProjectForGoModuleWithoutGoModFile(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		params:          []string{"module=github.com/acme/hello"},
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(`version: "1.0.0"
+goModule: true
+parameters:
+  - name: "module"
+    type: "modulePath"`), nil)
+	aM.On("LoadFile", archiveZip, "go.mod").Return([]byte(nil), errors.New("could not locate go.mod file"))
+	err := projectCreate.run()
+
+	aM.AssertExpectations(t)
+	assert.NotNil(t, err)
+	assert.Equal(t, "template is marked as Go module: could not locate go.mod file", err.Error())
+}
+
+func TestCreateProjectWritesProvenanceFile(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	digest, err := archive.Digest(archiveZip)
+	assert.Nil(t, err)
+	aM := new(ArchiverMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		params:          []string{"module=hello", "token=s3cr3t"},
+		out:             bytes.NewBuffer(nil),
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(`version: "1.0.0"
+parameters:
+  - name: "module"
+    type: "string"
+  - name: "token"
+    type: "string"
+    secret: true`), nil)
+	aM.On("ExtractLayers", []string{archiveZip}, targetDir, map[string]interface{}{"module": "hello", "token": "s3cr3t"}, noPostProcessors).Return(nil)
+	err = projectCreate.run()
+
+	aM.AssertExpectations(t)
+	assert.Nil(t, err)
+	p, err := config.LoadProvenanceFile(filepath.Join(targetDir, config.ProvenanceFileName))
+	assert.Nil(t, err)
+	assert.Equal(t, config.TemplateReference{Name: "hello-world", Version: "1.0.0", Digest: digest}, p.Template)
+	assert.Equal(t, map[string]string{"module": "hello"}, p.Answers())
+}
+
+func TestCreateProjectFromTemplateExtendingAnotherTemplate(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	childZip, parentZip := writeInheritingTemplatesFile(t, tmpHome, "base", "1.0.0")
+	aM := new(ArchiverMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		params:          []string{"name=hello", "port=9090"},
+		out:             bytes.NewBuffer(nil),
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}
+	aM.On("LoadManifestFile", childZip).Return([]byte(`version: "1.0.0"
+extends:
+  name: "base"
+  version: "1.0.0"
+parameters:
+  - name: "port"
+    type: "integer"`), nil)
+	aM.On("LoadManifestFile", parentZip).Return([]byte(`version: "1.0.0"
+parameters:
+  - name: "name"
+    type: "string"
+  - name: "port"
+    type: "string"`), nil)
+	aM.On("ExtractLayers", []string{parentZip, childZip}, targetDir, map[string]interface{}{"name": "hello", "port": 9090}, noPostProcessors).Return(nil)
+	err := projectCreate.run()
+
+	aM.AssertExpectations(t)
+	assert.Nil(t, err)
+}
+
+func TestCreateProjectFromTemplateExtendingUninstalledTemplate(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	childZip, _ := writeInheritingTemplatesFile(t, tmpHome, "base", "1.0.0")
+	aM := new(ArchiverMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		out:             bytes.NewBuffer(nil),
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}
+	aM.On("LoadManifestFile", childZip).Return([]byte(`version: "1.0.0"
+extends:
+  name: "base"
+  version: "2.0.0"`), nil)
+	err := projectCreate.run()
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "template \"hello-world\" extends template \"base\" with version \"2.0.0\" which hasn't been installed", err.Error())
+}
+
+func TestCreateProjectFromTemplatesExtendingEachOther(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	childZip, parentZip := writeInheritingTemplatesFile(t, tmpHome, "base", "1.0.0")
+	aM := new(ArchiverMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		out:             bytes.NewBuffer(nil),
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}
+	aM.On("LoadManifestFile", childZip).Return([]byte(`version: "1.0.0"
+extends:
+  name: "base"
+  version: "1.0.0"`), nil)
+	aM.On("LoadManifestFile", parentZip).Return([]byte(`version: "1.0.0"
+extends:
+  name: "hello-world"
+  version: "1.0.0"`), nil)
+	err := projectCreate.run()
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "template inheritance cycle detected: hello-world 1.0.0 -> base 1.0.0 -> hello-world 1.0.0", err.Error())
+}
+
+func TestCreateProjectFromTemplateExtendingIncompatibleTemplate(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	childZip, parentZip := writeInheritingTemplatesFile(t, tmpHome, "base", "1.0.0")
+	SetVersion("0.4.0")
+	defer SetVersion("")
+	aM := new(ArchiverMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		out:             bytes.NewBuffer(nil),
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}
+	aM.On("LoadManifestFile", childZip).Return([]byte(`version: "1.0.0"
+extends:
+  name: "base"
+  version: "1.0.0"`), nil)
+	aM.On("LoadManifestFile", parentZip).Return([]byte(`version: "3.0.0"
+minLetsgopherVersion: "0.6.0"
+parameters:
+  - name: "name"
+    type: "uuid"`), nil)
+	err := projectCreate.run()
+
+	aM.AssertNotCalled(t, "ExtractLayers", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.NotNil(t, err)
+	assert.Equal(t, "template \"base\" with version \"1.0.0\" is incompatible: letsgopher 0.6.0 or higher is required but this is letsgopher 0.4.0, please upgrade letsgopher", err.Error())
+}
+
+// writeInheritingTemplatesFile registers the hello-world template and the template it extends.
+func writeInheritingTemplatesFile(t *testing.T, tmpHome string, parentName string, parentVersion string) (string, string) {
+	childZip := storage.Home(tmpHome).ArchiveDir() + "/hello-world-1.0.0.zip"
+	parentZip := fmt.Sprintf("%s/%s-%s.zip", storage.Home(tmpHome).ArchiveDir(), parentName, parentVersion)
+	testhelper.WriteFile(t, storage.Home(tmpHome).TemplatesFile(), fmt.Sprintf(`generated: "2019-03-15T16:31:57.232715-06:00"
+templates:
+- archivePath: %s
+  name: hello-world
+  version: 1.0.0
+- archivePath: %s
+  name: %s
+  version: %s`, childZip, parentZip, parentName, parentVersion), 0644)
+	err := os.MkdirAll(storage.Home(tmpHome).ArchiveDir(), 0755)
+	if err != nil {
+		t.Fatalf("Failed to create archive directory. Reason: %s", err)
+	}
+	testhelper.CreateZip(t, childZip, []testhelper.TestFile{{Name: "manifest.yaml", Content: "version: \"1.0.0\""}})
+	testhelper.CreateZip(t, parentZip, []testhelper.TestFile{{Name: "manifest.yaml", Content: "version: \"1.0.0\""}})
+	return childZip, parentZip
+}
+
+func writeHelloWorldTemplatesFile(t *testing.T, tmpHome string) string {
+	f := storage.Home(tmpHome).TemplatesFile()
+	archiveZip := storage.Home(tmpHome).ArchiveDir() + "/hello-world-1.0.0.zip"
+	testhelper.WriteFile(t, f, fmt.Sprintf(`generated: "2019-03-15T16:31:57.232715-06:00"
+templates:
+- archivePath: %s
+  name: hello-world
+  version: 1.0.0`, archiveZip), 0644)
+	err := os.MkdirAll(storage.Home(tmpHome).ArchiveDir(), 0755)
+	if err != nil {
+		t.Fatalf("Failed to create archive directory. Reason: %s", err)
+	}
+	testhelper.CreateZip(t, archiveZip, []testhelper.TestFile{{Name: "manifest.yaml", Content: "version: \"1.0.0\""}})
+	return archiveZip
+}
+
+type ConfirmerMock struct {
+	mock.Mock
+}
+
+func (c *ConfirmerMock) Confirm(message string) (bool, error) {
+	args := c.Called(message)
+	return args.Bool(0), args.Error(1)
+}
+
+type ExecutorMock struct {
+	mock.Mock
+}
+
+func (e *ExecutorMock) Execute(command []string, dir string) error {
+	args := e.Called(command, dir)
+	return args.Error(0)
+}
+
+func TestCreateProjectAbortsOnFailingPreGenerateCheck(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		params:          []string{"goVersion=1.12"},
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(`version: "1.0.0"
+parameters:
+  - name: "goVersion"
+    prompt: "Please provide the Go version"
+    type: "semver"
+hooks:
+  preGenerate:
+    - check: 'semverCompare ">=1.21" .goVersion'
+      message: "the project requires Go 1.21 or higher"`), nil)
+	err := projectCreate.run()
+
+	aM.AssertExpectations(t)
+	assert.NotNil(t, err)
+	assert.Equal(t, "the project requires Go 1.21 or higher", err.Error())
+	assert.Equal(t, "", b.String())
+}
+
+func TestCreateProjectWithoutHooksStillEvaluatesPreGenerateChecks(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		params:          []string{"goVersion=1.12"},
+		noHooks:         true,
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(`version: "1.0.0"
+parameters:
+  - name: "goVersion"
+    prompt: "Please provide the Go version"
+    type: "semver"
+hooks:
+  preGenerate:
+    - check: 'semverCompare ">=1.21" .goVersion'
+      message: "the project requires Go 1.21 or higher"
+    - command: ["git", "rev-parse", "--is-inside-work-tree"]`), nil)
+	err := projectCreate.run()
+
+	aM.AssertExpectations(t)
+	assert.NotNil(t, err)
+	assert.Equal(t, "the project requires Go 1.21 or higher", err.Error())
+	assert.Equal(t, "", b.String())
+}
+
+func TestCreateProjectRunsPreGenerateCommandsBeforeExtraction(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	targetDir := filepath.Join(tmpHome, "projects", "new-project")
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	eM := new(ExecutorMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		trustHooks:      true,
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+		executor:        eM,
+	}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(`version: "1.0.0"
+hooks:
+  preGenerate:
+    - command: ["git", "rev-parse", "--is-inside-work-tree"]
+      message: "the project needs to be created inside of a git repository"`), nil)
+	eM.On("Execute", []string{"git", "rev-parse", "--is-inside-work-tree"}, tmpHome).Return(errors.New("exit status 128"))
+	err := projectCreate.run()
+
+	aM.AssertExpectations(t)
+	eM.AssertExpectations(t)
+	assert.NotNil(t, err)
+	assert.Equal(t, "the project needs to be created inside of a git repository", err.Error())
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/bmuschko/letsgopher/template/generate"
+	"github.com/bmuschko/letsgopher/template/param"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// generateDependencies generates the dependencies of a template into subdirectories of the target directory.
+// Dependencies declared by dependencies are generated relative to the directory of the declaring dependency.
+// The parameters of a dependency are resolved from the sources of the resolver of the template, values mapped by the dependency take precedence.
+// The hooks of dependencies are not run.
+func (c *projectCreateCmd) generateDependencies(templateName string, dependencies []*config.Dependency, replacements map[string]interface{}, resolver *param.Resolver) ([]*config.DependencyReference, error) {
+	return c.generateNestedDependencies([]string{templateName}, ".", dependencies, replacements, resolver)
+}
+
+func (c *projectCreateCmd) generateNestedDependencies(chain []string, dir string, dependencies []*config.Dependency, replacements map[string]interface{}, base *param.Resolver) ([]*config.DependencyReference, error) {
+	refs := []*config.DependencyReference{}
+	for _, d := range dependencies {
+		for _, name := range chain {
+			if name == d.Name {
+				return nil, fmt.Errorf("template dependency cycle detected: %s", strings.Join(append(chain, d.Name), " -> "))
+			}
+		}
+
+		template, err := c.dependencyTemplate(d)
+		if err != nil {
+			return nil, err
+		}
+		archives, m, err := loadTemplateChain(c.home, template, c.archiver)
+		if err != nil {
+			return nil, err
+		}
+		mapped, err := d.MapParameters(replacements)
+		if err != nil {
+			return nil, err
+		}
+		dependencyDir := path.Join(dir, d.Directory)
+		resolver := dependencyResolver(base, mapped, dependencyDir)
+		r, err := resolver.Resolve(m.Parameters)
+		if err != nil {
+			return nil, err
+		}
+
+		postProcessors, err := generate.PostProcessors(c.archiver, archives, m, r, c.format)
+		if err != nil {
+			return nil, err
+		}
+		err = c.archiver.ExtractLayers(archives, filepath.Join(c.targetDir, filepath.FromSlash(dependencyDir)), r, postProcessors...)
+		if err != nil {
+			return nil, err
+		}
+		ref, err := newTemplateReference(template)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, config.NewDependencyReference(ref, dependencyDir, m.Parameters, r))
+		fmt.Fprintf(c.out, "generated dependency %q with version %q in %q\n", template.Name, template.Version, dependencyDir)
+
+		nested, err := c.generateNestedDependencies(append(chain, d.Name), dependencyDir, m.Dependencies, r, base)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, nested...)
+	}
+	return refs, nil
+}
+
+// dependencyResolver resolves the parameters of a dependency generated into a directory relative to the target directory.
+// The mapped values take precedence over values provided with --param. The implicit import path points to the directory of the dependency.
+func dependencyResolver(base *param.Resolver, mapped map[string][]string, dir string) *param.Resolver {
+	params := make(map[string][]string)
+	for k, v := range base.Params {
+		params[k] = v
+	}
+	for k, v := range mapped {
+		params[k] = v
+	}
+	var implicit map[string]interface{}
+	if base.Implicit != nil {
+		implicit = make(map[string]interface{})
+		for k, v := range base.Implicit {
+			implicit[k] = v
+		}
+		if importPath, ok := implicit[generate.ImportPathParam].(string); ok {
+			implicit[generate.ImportPathParam] = path.Join(importPath, dir)
+		}
+	}
+	return &param.Resolver{Params: params, Answers: base.Answers, LookupEnv: base.LookupEnv, Prompter: base.Prompter, Implicit: implicit}
+}
+
+// dependencyTemplate determines the installed template with the highest version satisfying the version range of a dependency.
+// The template is installed from the source of the dependency if none of the installed templates satisfies the version range.
+func (c *projectCreateCmd) dependencyTemplate(d *config.Dependency) (*config.Template, error) {
+	f, err := config.LoadTemplatesFile(c.home.TemplatesFile())
+	if err != nil {
+		return nil, err
+	}
+	template, err := f.Latest(d.Name, d.Version)
+	if err != nil {
+		return nil, err
+	}
+	if template != nil {
+		return template, nil
+	}
+	if d.Source == "" {
+		return nil, fmt.Errorf("no installed version of template %q satisfies the version range %q of the dependency", d.Name, d.Version)
+	}
+
+	templateVersion, err := extractTemplateVersion(d.Source)
+	if err != nil {
+		return nil, err
+	}
+	ok, err := d.Allows(templateVersion)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("source %q of dependency %q provides version %q which does not satisfy the version range %q", d.Source, d.Name, templateVersion, d.Version)
+	}
+	templateZIP, err := c.downloader.Download(d.Source)
+	if err != nil {
+		return nil, err
+	}
+	template = &config.Template{Name: d.Name, Version: templateVersion, ArchivePath: templateZIP, SourceURL: d.Source}
+	err = checkArchiveCompatibility(c.archiver, template, templateZIP)
+	if err != nil {
+		os.Remove(templateZIP)
+		return nil, err
+	}
+	err = addTemplate(d.Name, templateVersion, templateZIP, d.Source, c.home)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(c.out, "%q has been added to your templates\n", d.Name)
+	return template, nil
+}
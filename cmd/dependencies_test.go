@@ -0,0 +1,321 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/template/archive"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/bmuschko/letsgopher/template/storage"
+	"github.com/bmuschko/letsgopher/testhelper"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func TestCreateProjectWithInstalledDependency(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	oldCIZip := installDependencyTemplate(t, tmpHome, "ci", "1.2.0")
+	ciZip := installDependencyTemplate(t, tmpHome, "ci", "1.3.0")
+	installDependencyTemplate(t, tmpHome, "ci", "2.0.0")
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		params:          []string{"name=hello"},
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(`version: "1.0.0"
+parameters:
+  - name: "name"
+    type: "string"
+dependencies:
+  - name: "ci"
+    version: ">=1.2 <2"
+    directory: ".github"
+    parameters:
+      project: "{{ .name }}-ci"`), nil)
+	aM.On("LoadManifestFile", ciZip).Return([]byte(`version: "1.0.0"
+parameters:
+  - name: "project"
+    type: "string"`), nil)
+	aM.On("ExtractLayers", []string{archiveZip}, targetDir, map[string]interface{}{"name": "hello"}, noPostProcessors).Return(nil)
+	aM.On("ExtractLayers", []string{ciZip}, filepath.Join(targetDir, ".github"), map[string]interface{}{"project": "hello-ci"}, noPostProcessors).Return(nil)
+	err := projectCreate.run()
+
+	aM.AssertExpectations(t)
+	aM.AssertNotCalled(t, "LoadManifestFile", oldCIZip)
+	assert.Nil(t, err)
+	assert.Contains(t, b.String(), "generated dependency \"ci\" with version \"1.3.0\" in \".github\"\n")
+	p, err := config.LoadProvenanceFile(filepath.Join(targetDir, config.ProvenanceFileName))
+	assert.Nil(t, err)
+	digest, err := archive.Digest(ciZip)
+	assert.Nil(t, err)
+	assert.Equal(t, []*config.DependencyReference{{
+		TemplateReference: config.TemplateReference{Name: "ci", Version: "1.3.0", Digest: digest},
+		Directory:         ".github",
+		Parameters:        map[string]interface{}{"project": "hello-ci"},
+	}}, p.Dependencies)
+}
+
+func TestCreateProjectInstallsMissingDependencyFromSource(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	ciZip := filepath.Join(storage.Home(tmpHome).ArchiveDir(), "ci-1.4.0.zip")
+	testhelper.CreateZip(t, ciZip, []testhelper.TestFile{{Name: "manifest.yaml", Content: "version: \"1.0.0\""}})
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	dM := new(DownloaderMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+		downloader:      dM,
+	}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(`version: "1.0.0"
+dependencies:
+  - name: "ci"
+    version: "1.x"
+    source: "http://my.repo.com/ci-1.4.0.zip"`), nil)
+	aM.On("LoadManifestFile", ciZip).Return([]byte(`version: "1.0.0"`), nil)
+	aM.On("ExtractLayers", []string{archiveZip}, targetDir, make(map[string]interface{}), noPostProcessors).Return(nil)
+	aM.On("ExtractLayers", []string{ciZip}, targetDir, make(map[string]interface{}), noPostProcessors).Return(nil)
+	dM.On("Download", "http://my.repo.com/ci-1.4.0.zip").Return(ciZip, nil)
+	err := projectCreate.run()
+
+	aM.AssertExpectations(t)
+	dM.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Contains(t, b.String(), "\"ci\" has been added to your templates\n")
+	f, err := config.LoadTemplatesFile(storage.Home(tmpHome).TemplatesFile())
+	assert.Nil(t, err)
+	assert.Equal(t, &config.Template{Name: "ci", Version: "1.4.0", ArchivePath: ciZip, SourceURL: "http://my.repo.com/ci-1.4.0.zip"}, f.Get("ci", "1.4.0"))
+}
+
+func TestCreateProjectDoesNotInstallIncompatibleDependency(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	templatesContent := testhelper.ReadFile(t, storage.Home(tmpHome).TemplatesFile())
+	ciZip := filepath.Join(storage.Home(tmpHome).ArchiveDir(), "ci-1.4.0.zip")
+	testhelper.CreateZip(t, ciZip, []testhelper.TestFile{{Name: "manifest.yaml", Content: "version: \"1.0.0\""}})
+	SetVersion("0.4.0")
+	defer SetVersion("")
+	aM := new(ArchiverMock)
+	dM := new(DownloaderMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		out:             bytes.NewBuffer(nil),
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+		downloader:      dM,
+	}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(`version: "1.0.0"
+dependencies:
+  - name: "ci"
+    version: "1.x"
+    source: "http://my.repo.com/ci-1.4.0.zip"`), nil)
+	aM.On("LoadManifestFile", ciZip).Return([]byte(`version: "1.0.0"
+minLetsgopherVersion: "0.5.0"`), nil)
+	aM.On("ExtractLayers", []string{archiveZip}, targetDir, make(map[string]interface{}), noPostProcessors).Return(nil)
+	dM.On("Download", "http://my.repo.com/ci-1.4.0.zip").Return(ciZip, nil)
+	err := projectCreate.run()
+
+	dM.AssertExpectations(t)
+	assert.NotNil(t, err)
+	assert.Equal(t, "template \"ci\" with version \"1.4.0\" is incompatible: letsgopher 0.5.0 or higher is required but this is letsgopher 0.4.0, please upgrade letsgopher", err.Error())
+	assert.Equal(t, templatesContent, testhelper.ReadFile(t, storage.Home(tmpHome).TemplatesFile()))
+	testhelper.FileNotExists(t, ciZip)
+}
+
+func TestCreateProjectWithUnsatisfiableDependency(t *testing.T) {
+	sources := []struct {
+		source   string
+		expected string
+	}{
+		{"", "no installed version of template \"ci\" satisfies the version range \"1.x\" of the dependency"},
+		{"http://my.repo.com/ci-2.0.0.zip", "source \"http://my.repo.com/ci-2.0.0.zip\" of dependency \"ci\" provides version \"2.0.0\" which does not satisfy the version range \"1.x\""},
+	}
+
+	for _, s := range sources {
+		t.Run(s.source, func(t *testing.T) {
+			tmpHome := filet.TmpDir(t, "")
+			targetDir := filet.TmpDir(t, "")
+			defer filet.CleanUp(t)
+
+			archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+			aM := new(ArchiverMock)
+			dM := new(DownloaderMock)
+			projectCreate := &projectCreateCmd{
+				templateName:    "hello-world",
+				templateVersion: "1.0.0",
+				targetDir:       targetDir,
+				out:             bytes.NewBuffer(nil),
+				home:            storage.Home(tmpHome),
+				archiver:        aM,
+				downloader:      dM,
+			}
+			aM.On("LoadManifestFile", archiveZip).Return([]byte(fmt.Sprintf(`version: "1.0.0"
+dependencies:
+  - name: "ci"
+    version: "1.x"
+    source: %q`, s.source)), nil)
+			aM.On("ExtractLayers", []string{archiveZip}, targetDir, make(map[string]interface{}), noPostProcessors).Return(nil)
+			err := projectCreate.run()
+
+			dM.AssertNotCalled(t, "Download", s.source)
+			assert.NotNil(t, err)
+			assert.Equal(t, s.expected, err.Error())
+		})
+	}
+}
+
+func TestCreateProjectWithCyclicDependencies(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	ciZip := installDependencyTemplate(t, tmpHome, "ci", "1.0.0")
+	aM := new(ArchiverMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		out:             bytes.NewBuffer(nil),
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(`version: "1.0.0"
+dependencies:
+  - name: "ci"
+    version: "1.x"`), nil)
+	aM.On("LoadManifestFile", ciZip).Return([]byte(`version: "1.0.0"
+dependencies:
+  - name: "hello-world"
+    version: "1.x"`), nil)
+	aM.On("ExtractLayers", []string{archiveZip}, targetDir, make(map[string]interface{}), noPostProcessors).Return(nil)
+	aM.On("ExtractLayers", []string{ciZip}, targetDir, make(map[string]interface{}), noPostProcessors).Return(nil)
+	err := projectCreate.run()
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "template dependency cycle detected: hello-world -> ci -> hello-world", err.Error())
+}
+
+// installDependencyTemplate registers an additional template in the templates file of the home directory.
+func installDependencyTemplate(t *testing.T, tmpHome string, name string, version string) string {
+	archiveZip := filepath.Join(storage.Home(tmpHome).ArchiveDir(), fmt.Sprintf("%s-%s.zip", name, version))
+	testhelper.CreateZip(t, archiveZip, []testhelper.TestFile{{Name: "manifest.yaml", Content: "version: \"1.0.0\""}})
+	err := addTemplate(name, version, archiveZip, "", storage.Home(tmpHome))
+	if err != nil {
+		t.Fatalf("Failed to install template %s. Reason: %s", name, err)
+	}
+	return archiveZip
+}
+
+func TestCreateProjectResolvesUnmappedDependencyParametersFromUserInput(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	targetDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	ciZip := installDependencyTemplate(t, tmpHome, "ci", "1.0.0")
+	answersFile := filepath.Join(tmpHome, "answers.yaml")
+	testhelper.WriteFile(t, answersFile, "registry: ghcr.io", 0644)
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	projectCreate := &projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		params:          []string{"name=hello", "project=from-param", "branch=develop"},
+		answersFile:     answersFile,
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(`version: "1.0.0"
+parameters:
+  - name: "name"
+    type: "string"
+dependencies:
+  - name: "ci"
+    version: "1.0.0"
+    parameters:
+      project: "{{ .name }}-ci"`), nil)
+	aM.On("LoadManifestFile", ciZip).Return([]byte(`version: "1.0.0"
+parameters:
+  - name: "project"
+    type: "string"
+  - name: "branch"
+    type: "string"
+  - name: "registry"
+    type: "string"`), nil)
+	aM.On("ExtractLayers", []string{archiveZip}, targetDir, map[string]interface{}{"name": "hello"}, noPostProcessors).Return(nil)
+	aM.On("ExtractLayers", []string{ciZip}, targetDir, map[string]interface{}{"project": "hello-ci", "branch": "develop", "registry": "ghcr.io"}, noPostProcessors).Return(nil)
+	err := projectCreate.run()
+
+	aM.AssertExpectations(t)
+	assert.Nil(t, err)
+}
+
+func TestAddComponentProvidesModuleParamsToDependencies(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	testhelper.WriteFile(t, filepath.Join(tmpDir, "go.mod"), "module github.com/acme/hello\n\ngo 1.13\n", 0644)
+	targetDir := filepath.Join(tmpDir, "internal", "handler")
+	archiveZip := writeHelloWorldTemplatesFile(t, tmpHome)
+	mocksZip := installDependencyTemplate(t, tmpHome, "mocks", "1.0.0")
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	componentAdd := &componentAddCmd{projectCreateCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		targetDir:       targetDir,
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}}
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(`version: "1.0.0"
+dependencies:
+  - name: "mocks"
+    version: "1.0.0"
+    directory: "mocks"`), nil)
+	aM.On("LoadManifestFile", mocksZip).Return([]byte(`version: "1.0.0"`), nil)
+	replacements := map[string]interface{}{
+		"modulePath":      "github.com/acme/hello",
+		"moduleGoVersion": "1.13",
+		"importPath":      "github.com/acme/hello/internal/handler",
+	}
+	aM.On("CheckConflicts", []string{archiveZip}, targetDir, replacements).Return(nil)
+	aM.On("ExtractLayers", []string{archiveZip}, targetDir, replacements, noPostProcessors).Return(nil)
+	aM.On("ExtractLayers", []string{mocksZip}, filepath.Join(targetDir, "mocks"), map[string]interface{}{
+		"modulePath":      "github.com/acme/hello",
+		"moduleGoVersion": "1.13",
+		"importPath":      "github.com/acme/hello/internal/handler/mocks",
+	}, noPostProcessors).Return(nil)
+	err := componentAdd.run()
+
+	aM.AssertExpectations(t)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/bmuschko/letsgopher/template/archive"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/bmuschko/letsgopher/template/diff"
+	"github.com/bmuschko/letsgopher/template/environment"
+	"github.com/bmuschko/letsgopher/template/param"
+	"github.com/bmuschko/letsgopher/template/prompt"
+	"github.com/bmuschko/letsgopher/template/storage"
+	"github.com/spf13/cobra"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	templatePrefix = "template/"
+	projectPrefix  = "project/"
+	devNull        = "/dev/null"
+	maxStatWidth   = 50
+)
+
+type projectDiffCmd struct {
+	projectDir string
+	stat       bool
+	paths      []string
+	format     bool
+	formatSet  bool
+	out        io.Writer
+	home       storage.Home
+	archiver   archive.Archiver
+	prompter   prompt.Prompter
+}
+
+type fileDiff struct {
+	path       string
+	template   []byte
+	project    []byte
+	deleted    bool
+	binary     bool
+	insertions int
+	deletions  int
+}
+
+func newDiffCmd(out io.Writer) *cobra.Command {
+	d := &projectDiffCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "diff [dir]",
+		Short: "show the changes of a generated project compared to its template",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("this command accepts at most 1 argument: the project directory")
+			}
+
+			d.projectDir = "."
+			if len(args) == 1 {
+				d.projectDir = args[0]
+			}
+			d.formatSet = cmd.Flags().Changed("format")
+			d.home = environment.Settings.Home
+			d.archiver = &archive.ZIPArchiver{Processor: &archive.TemplateProcessor{}}
+			d.prompter = &prompt.InteractivePrompter{}
+			return d.run()
+		},
+	}
+
+	cmd.PersistentFlags().BoolVar(&d.stat, "stat", false, "print the number of changed lines per file instead of the changes")
+	cmd.PersistentFlags().StringSliceVar(&d.paths, "path", []string{}, "only compare files matching the path, directory or glob pattern, can be repeated")
+	cmd.PersistentFlags().BoolVar(&d.format, "format", false, "format generated Go source files with gofmt before comparing, defaults to the option the project has been generated with")
+	return cmd
+}
+
+func (c *projectDiffCmd) run() error {
+	provenance, err := loadProvenanceFile(c.projectDir)
+	if err != nil {
+		return err
+	}
+	f, err := config.LoadTemplatesFile(c.home.TemplatesFile())
+	if err != nil {
+		return err
+	}
+	template, err := recordedTemplate(f, provenance)
+	if err != nil {
+		return err
+	}
+	archives, templateManifest, err := loadTemplateChain(c.home, template, c.archiver)
+	if err != nil {
+		return err
+	}
+	resolver := &param.Resolver{Answers: provenance.Answers(), Prompter: c.prompter}
+	r, err := resolver.Resolve(templateManifest.Parameters)
+	if err != nil {
+		return err
+	}
+
+	tmpDir, err := ioutil.TempDir("", "letsgopher-diff")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	err = renderTemplate(c.archiver, archives, templateManifest, r, tmpDir, recordedFormat(provenance, c.format, c.formatSet))
+	if err != nil {
+		return err
+	}
+
+	diffs, err := c.compare(tmpDir)
+	if err != nil {
+		return err
+	}
+	if len(diffs) == 0 {
+		fmt.Fprintf(c.out, "project does not differ from version %q of template %q\n", template.Version, template.Name)
+		return nil
+	}
+	if c.stat {
+		printStat(c.out, diffs)
+		return nil
+	}
+	for _, d := range diffs {
+		printDiff(c.out, d)
+	}
+	return nil
+}
+
+// compare determines the differences between the rendered template files and the corresponding project files.
+// Files that don't originate from the template are not compared.
+func (c *projectDiffCmd) compare(renderedDir string) ([]*fileDiff, error) {
+	paths := []string{}
+	err := filepath.Walk(renderedDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(renderedDir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if matchesPaths(rel, c.paths) {
+			paths = append(paths, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	diffs := []*fileDiff{}
+	for _, p := range paths {
+		t, err := ioutil.ReadFile(filepath.Join(renderedDir, filepath.FromSlash(p)))
+		if err != nil {
+			return nil, err
+		}
+		d := &fileDiff{path: p, template: t}
+		d.project, err = ioutil.ReadFile(filepath.Join(c.projectDir, filepath.FromSlash(p)))
+		if os.IsNotExist(err) {
+			d.deleted = true
+		} else if err != nil {
+			return nil, err
+		}
+		if !d.deleted && bytes.Equal(d.template, d.project) {
+			continue
+		}
+		d.binary = archive.IsBinary(d.template) || archive.IsBinary(d.project)
+		if !d.binary {
+			d.insertions, d.deletions = diff.Stat(d.template, d.project)
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs, nil
+}
+
+// matchesPaths determines whether a slash-separated path equals, is located in or matches the glob pattern of one of the filters.
+// All paths match if no filters are given.
+func matchesPaths(p string, filters []string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, f := range filters {
+		f = strings.TrimSuffix(filepath.ToSlash(path.Clean(f)), "/")
+		if p == f || strings.HasPrefix(p, f+"/") {
+			return true
+		}
+		if ok, err := path.Match(f, p); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+func printDiff(out io.Writer, d *fileDiff) {
+	to := projectPrefix + d.path
+	if d.deleted {
+		to = devNull
+	}
+	if d.binary {
+		fmt.Fprintf(out, "Binary files %s and %s differ\n", templatePrefix+d.path, to)
+		return
+	}
+	fmt.Fprint(out, diff.Unified(templatePrefix+d.path, to, d.template, d.project))
+}
+
+func printStat(out io.Writer, diffs []*fileDiff) {
+	width, most := 0, 0
+	insertions, deletions := 0, 0
+	for _, d := range diffs {
+		if len(d.path) > width {
+			width = len(d.path)
+		}
+		if d.insertions+d.deletions > most {
+			most = d.insertions + d.deletions
+		}
+		insertions += d.insertions
+		deletions += d.deletions
+	}
+	for _, d := range diffs {
+		if d.binary {
+			fmt.Fprintf(out, " %-*s | Bin\n", width, d.path)
+			continue
+		}
+		plus, minus := d.insertions, d.deletions
+		if most > maxStatWidth {
+			plus = scale(plus, most)
+			minus = scale(minus, most)
+		}
+		fmt.Fprintf(out, " %-*s | %d %s%s\n", width, d.path, d.insertions+d.deletions, strings.Repeat("+", plus), strings.Repeat("-", minus))
+	}
+	fmt.Fprintf(out, " %d file(s) changed, %d insertion(s)(+), %d deletion(s)(-)\n", len(diffs), insertions, deletions)
+}
+
+// scale reduces a number of changed lines proportionally to fit the maximum width, keeping at least one character.
+func scale(n int, most int) int {
+	if n == 0 {
+		return 0
+	}
+	s := n * maxStatWidth / most
+	if s == 0 {
+		return 1
+	}
+	return s
+}
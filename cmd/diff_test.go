@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"bytes"
+	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/bmuschko/letsgopher/template/storage"
+	"github.com/bmuschko/letsgopher/testhelper"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var diffTemplateFiles = []testhelper.TestFile{
+	{Name: "manifest.yaml", Content: `version: "1.0.0"
+parameters:
+  - name: "name"
+    prompt: "Name"
+    type: "string"`},
+	{Name: "README.md", Content: "# {{ .name }}\n\nGenerated project.\n"},
+	{Name: "main.go", Content: "package main\n\nfunc main() {\n\tprintln(\"{{ .name }}\")\n}\n"},
+	{Name: "docs/usage.md", Content: "Run {{ .name }}.\n"},
+}
+
+func TestDiffProjectAgainstTemplate(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	projectDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	writeDiffProject(t, tmpHome, projectDir)
+	b := bytes.NewBuffer(nil)
+	projectDiff := &projectDiffCmd{
+		projectDir: projectDir,
+		out:        b,
+		home:       storage.Home(tmpHome),
+		archiver:   newZIPArchiver(),
+	}
+	err := projectDiff.run()
+
+	assert.Nil(t, err)
+	assert.Equal(t, `--- template/README.md
++++ /dev/null
+@@ -1,3 +0,0 @@
+-# hello
+-
+-Generated project.
+--- template/main.go
++++ project/main.go
+@@ -1,5 +1,5 @@
+ package main
+ 
+ func main() {
+-	println("hello")
++	println("hello world")
+ }
+`, b.String())
+}
+
+func TestDiffProjectAgainstTemplateWithStat(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	projectDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	writeDiffProject(t, tmpHome, projectDir)
+	b := bytes.NewBuffer(nil)
+	projectDiff := &projectDiffCmd{
+		projectDir: projectDir,
+		stat:       true,
+		out:        b,
+		home:       storage.Home(tmpHome),
+		archiver:   newZIPArchiver(),
+	}
+	err := projectDiff.run()
+
+	assert.Nil(t, err)
+	assert.Equal(t, ` README.md | 3 ---
+ main.go   | 2 +-
+ 2 file(s) changed, 1 insertion(s)(+), 4 deletion(s)(-)
+`, b.String())
+}
+
+func TestDiffProjectAgainstTemplateWithPathFilter(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	projectDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	writeDiffProject(t, tmpHome, projectDir)
+	b := bytes.NewBuffer(nil)
+	projectDiff := &projectDiffCmd{
+		projectDir: projectDir,
+		paths:      []string{"docs", "*.md"},
+		out:        b,
+		home:       storage.Home(tmpHome),
+		archiver:   newZIPArchiver(),
+	}
+	err := projectDiff.run()
+
+	assert.Nil(t, err)
+	assert.Contains(t, b.String(), "--- template/README.md")
+	assert.NotContains(t, b.String(), "main.go")
+}
+
+func TestDiffUnchangedProject(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	projectDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	writeDiffProject(t, tmpHome, projectDir)
+	b := bytes.NewBuffer(nil)
+	projectDiff := &projectDiffCmd{
+		projectDir: projectDir,
+		paths:      []string{"docs/usage.md"},
+		out:        b,
+		home:       storage.Home(tmpHome),
+		archiver:   newZIPArchiver(),
+	}
+	err := projectDiff.run()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "project does not differ from version \"1.0.0\" of template \"hello-world\"\n", b.String())
+}
+
+func TestDiffProjectGeneratedWithFormatting(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	projectDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	writeUpdateTemplates(t, tmpHome, []testhelper.TestFile{
+		{Name: "manifest.yaml", Content: "version: \"1.0.0\""},
+		{Name: "main.go", Content: "package main\n\nfunc main()  {\n}\n"},
+	}, nil)
+	testhelper.WriteFile(t, filepath.Join(projectDir, "main.go"), "package main\n\nfunc main() {\n}\n", 0644)
+	writeProvenance(t, tmpHome, projectDir, "1.0.0", map[string]interface{}{})
+	provenanceFile := filepath.Join(projectDir, config.ProvenanceFileName)
+	provenance, err := config.LoadProvenanceFile(provenanceFile)
+	assert.Nil(t, err)
+	provenance.Format = true
+	assert.Nil(t, provenance.WriteFile(provenanceFile, 0644))
+
+	b := bytes.NewBuffer(nil)
+	projectDiff := &projectDiffCmd{
+		projectDir: projectDir,
+		out:        b,
+		home:       storage.Home(tmpHome),
+		archiver:   newZIPArchiver(),
+	}
+	err = projectDiff.run()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "project does not differ from version \"1.0.0\" of template \"hello-world\"\n", b.String())
+
+	b.Reset()
+	projectDiff.formatSet = true
+	err = projectDiff.run()
+
+	assert.Nil(t, err)
+	assert.Contains(t, b.String(), "-func main()  {\n+func main() {\n")
+}
+
+func TestDiffBinaryFile(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	projectDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	writeUpdateTemplates(t, tmpHome, []testhelper.TestFile{
+		{Name: "manifest.yaml", Content: "version: \"1.0.0\""},
+		{Name: "logo.png", Content: "PNG"},
+	}, nil)
+	testhelper.WriteFile(t, filepath.Join(projectDir, "logo.png"), "P\x00NG", 0644)
+	writeProvenance(t, tmpHome, projectDir, "1.0.0", map[string]interface{}{})
+	b := bytes.NewBuffer(nil)
+	projectDiff := &projectDiffCmd{
+		projectDir: projectDir,
+		out:        b,
+		home:       storage.Home(tmpHome),
+		archiver:   newZIPArchiver(),
+	}
+	err := projectDiff.run()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Binary files template/logo.png and project/logo.png differ\n", b.String())
+}
+
+func TestMatchesPaths(t *testing.T) {
+	assert.True(t, matchesPaths("main.go", []string{}))
+	assert.True(t, matchesPaths("cmd/root.go", []string{"cmd"}))
+	assert.True(t, matchesPaths("cmd/root.go", []string{"cmd/"}))
+	assert.True(t, matchesPaths("cmd/root.go", []string{"cmd/*.go"}))
+	assert.False(t, matchesPaths("cmdline/root.go", []string{"cmd"}))
+	assert.False(t, matchesPaths("main.go", []string{"cmd", "*.md"}))
+}
+
+func writeDiffProject(t *testing.T, tmpHome string, projectDir string) {
+	writeUpdateTemplates(t, tmpHome, diffTemplateFiles, nil)
+	testhelper.WriteFile(t, filepath.Join(projectDir, "main.go"), "package main\n\nfunc main() {\n\tprintln(\"hello world\")\n}\n", 0644)
+	err := os.MkdirAll(filepath.Join(projectDir, "docs"), 0755)
+	if err != nil {
+		t.Fatalf("Failed to create directory. Reason: %s", err)
+	}
+	testhelper.WriteFile(t, filepath.Join(projectDir, "docs", "usage.md"), "Run hello.\n", 0644)
+	testhelper.WriteFile(t, filepath.Join(projectDir, "notes.txt"), "not part of the template\n", 0644)
+	writeProvenance(t, tmpHome, projectDir, "1.0.0", map[string]interface{}{"name": "hello"})
+}
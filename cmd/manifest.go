@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"io"
+)
+
+func newManifestCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "manifest schema",
+		Short: "work with template manifests",
+	}
+
+	cmd.AddCommand(newManifestSchemaCmd(out))
+	return cmd
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/spf13/cobra"
+	"io"
+)
+
+type manifestSchemaCmd struct {
+	out io.Writer
+}
+
+func newManifestSchemaCmd(out io.Writer) *cobra.Command {
+	schema := &manifestSchemaCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "print the JSON schema of the manifest file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return errors.New("this command does not accept arguments")
+			}
+			return schema.run()
+		},
+	}
+	return cmd
+}
+
+func (s *manifestSchemaCmd) run() error {
+	b, err := config.JSONSchema()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(s.out, string(b))
+	return err
+}
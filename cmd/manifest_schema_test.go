@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"bytes"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestManifestSchema(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	schema := &manifestSchemaCmd{
+		out: b,
+	}
+	err := schema.run()
+
+	assert.Nil(t, err)
+	expected, err := config.JSONSchema()
+	assert.Nil(t, err)
+	assert.Equal(t, string(expected)+"\n", b.String())
+}
@@ -20,7 +20,16 @@ Common actions from this point include:
 - letsgopher template install:   installs a new template
 - letsgopher template inspect:   inspects an already installed template
 - letsgopher template list:      lists all installed templates
+- letsgopher template lint:      checks a template directory or archive for problems
+- letsgopher template package:   packages a template directory into a versioned archive
+- letsgopher template new:       generates the skeleton of a new template
+- letsgopher template extract:   creates a template from an existing project
+- letsgopher template test:      compares the test cases of a template with their expected files
 - letsgopher create:             creates a new project from a template
+- letsgopher add:                adds a component from a template to an existing Go module
+- letsgopher update:             updates a generated project to a different template version
+- letsgopher diff:               shows the changes of a generated project compared to its template
+- letsgopher manifest schema:    prints the JSON schema of the template manifest
 
 `
 
@@ -40,6 +49,10 @@ func NewRootCmd(args []string) *cobra.Command {
 		newInitCmd(out),
 		newTemplateCmd(out),
 		newCreateCmd(out),
+		newAddCmd(out),
+		newUpdateCmd(out),
+		newDiffCmd(out),
+		newManifestCmd(out),
 		newVersionCmd(out),
 	)
 
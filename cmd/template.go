@@ -7,13 +7,18 @@ import (
 
 func newTemplateCmd(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "template install|uninstall|list|inspect [args]",
-		Short: "install, uninstall, list, inspect template",
+		Use:   "template install|uninstall|list|inspect|lint|package|new|extract|test [args]",
+		Short: "install, uninstall, list, inspect, lint, package, new, extract, test template",
 	}
 
 	cmd.AddCommand(newTemplateInstallCmd(out))
 	cmd.AddCommand(newTemplateUninstallCmd(out))
 	cmd.AddCommand(newTemplateListCmd(out))
 	cmd.AddCommand(newTemplateInspectCmd(out))
+	cmd.AddCommand(newTemplateLintCmd(out))
+	cmd.AddCommand(newTemplatePackageCmd(out))
+	cmd.AddCommand(newTemplateNewCmd(out))
+	cmd.AddCommand(newTemplateExtractCmd(out))
+	cmd.AddCommand(newTemplateTestCmd(out))
 	return cmd
 }
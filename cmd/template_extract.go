@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/bmuschko/letsgopher/template/extract"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+type templateExtractCmd struct {
+	projectDir   string
+	targetDir    string
+	templateName string
+	replacements []string
+	out          io.Writer
+}
+
+func newTemplateExtractCmd(out io.Writer) *cobra.Command {
+	e := &templateExtractCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "extract [project-dir] [target-dir]",
+		Short: "creates a template from an existing project",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 && len(args) != 2 {
+				return fmt.Errorf("this command needs 1 or 2 arguments: the project directory, optionally the target directory")
+			}
+
+			e.projectDir = args[0]
+			if len(args) == 2 {
+				e.targetDir = args[1]
+			}
+			return e.run()
+		},
+	}
+
+	cmd.PersistentFlags().StringSliceVar(&e.replacements, "replace", []string{}, "literal replaced with a parameter defined as parameter/literal pair separated by = character, i.e. appName=orders")
+	cmd.PersistentFlags().StringVar(&e.templateName, "name", "", "name of the template declared by the manifest, defaults to the name of the target directory")
+	return cmd
+}
+
+func (c *templateExtractCmd) run() error {
+	project, err := filepath.Abs(c.projectDir)
+	if err != nil {
+		return err
+	}
+	targetDir := c.targetDir
+	if targetDir == "" {
+		targetDir = filepath.Join(filepath.Dir(project), filepath.Base(project)+"-template")
+	}
+	if _, err := os.Stat(targetDir); err == nil {
+		return fmt.Errorf("target directory %q already exists", targetDir)
+	}
+	name := c.templateName
+	if name == "" {
+		target, err := filepath.Abs(targetDir)
+		if err != nil {
+			return err
+		}
+		name = filepath.Base(target)
+	}
+
+	replacements := []*extract.Replacement{}
+	for _, r := range c.replacements {
+		replacement, err := extract.ParseReplacement(r)
+		if err != nil {
+			return err
+		}
+		replacements = append(replacements, replacement)
+	}
+
+	extractor := &extract.Extractor{Name: name, Replacements: replacements}
+	result, err := extractor.Extract(c.projectDir, targetDir)
+	if err != nil {
+		return err
+	}
+	for _, r := range replacements {
+		if result.Occurrences[r.Parameter] == 0 {
+			fmt.Fprintf(c.out, "warning: literal %q of parameter %q was not found in the project\n", r.Literal, r.Parameter)
+		}
+	}
+	fmt.Fprintf(c.out, "extracted template %q with %d file(s) to %q\n", name, len(result.Files), targetDir)
+	return nil
+}
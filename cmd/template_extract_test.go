@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/bmuschko/letsgopher/testhelper"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtractTemplate(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	projectDir := filepath.Join(tmpDir, "orders")
+	err := os.MkdirAll(filepath.Join(projectDir, "cmd", "orders"), 0755)
+	assert.Nil(t, err)
+	testhelper.WriteFile(t, filepath.Join(projectDir, "go.mod"), "module github.com/acme/orders\n", 0644)
+	testhelper.WriteFile(t, filepath.Join(projectDir, "cmd", "orders", "main.go"), "package main\n", 0644)
+	b := bytes.NewBuffer(nil)
+	templateExtract := &templateExtractCmd{
+		projectDir:   projectDir,
+		replacements: []string{"module=github.com/acme/orders", "appName=orders", "org=initech"},
+		out:          b,
+	}
+	err = templateExtract.run()
+
+	targetDir := filepath.Join(tmpDir, "orders-template")
+	assert.Nil(t, err)
+	assert.Equal(t, "warning: literal \"initech\" of parameter \"org\" was not found in the project\nextracted template \"orders-template\" with 2 file(s) to \""+targetDir+"\"\n", b.String())
+	assert.Equal(t, "module {{ .module }}\n", testhelper.ReadFile(t, filepath.Join(targetDir, "go.mod")))
+	assert.FileExists(t, filepath.Join(targetDir, "cmd", "{{ .appName }}", "main.go"))
+	m, err := config.LoadManifestData([]byte(testhelper.ReadFile(t, filepath.Join(targetDir, "manifest.yaml"))))
+	assert.Nil(t, err)
+	assert.Equal(t, "orders-template", m.Name)
+	assert.Len(t, m.Parameters, 3)
+}
+
+func TestExtractTemplateWithInvalidInput(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	inputs := []struct {
+		name         string
+		targetDir    string
+		replacements []string
+		expected     string
+	}{
+		{"existing target", tmpDir, []string{"appName=orders"}, "target directory \"" + tmpDir + "\" already exists"},
+		{"invalid replacement", filepath.Join(tmpDir, "template"), []string{"appName"}, "replacement \"appName\" needs to be defined as parameter=literal"},
+	}
+
+	for _, input := range inputs {
+		t.Run(input.name, func(t *testing.T) {
+			b := bytes.NewBuffer(nil)
+			templateExtract := &templateExtractCmd{
+				projectDir:   filepath.Join(tmpDir, "orders"),
+				targetDir:    input.targetDir,
+				replacements: input.replacements,
+				out:          b,
+			}
+			err := templateExtract.run()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, input.expected, err.Error())
+			assert.Empty(t, b.String())
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	"io"
 	"path"
+	"strings"
 )
 
 type templateInspectCmd struct {
@@ -57,11 +58,83 @@ func (a *templateInspectCmd) run() error {
 	if err != nil {
 		return err
 	}
+	m, err := config.LoadManifestData(tb)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s of template %q with version %q: %s", config.ManifestFileName, a.templateName, a.templateVersion, err)
+	}
 
 	fmt.Fprintln(a.out, "template:")
 	fmt.Fprintln(a.out, fmt.Sprintf("  name: %q", a.templateName))
 	fmt.Fprintln(a.out, fmt.Sprintf("  version: %q", a.templateVersion))
+	printMetadata(a.out, m)
 	fmt.Fprintln(a.out, "manifest:")
 	fmt.Fprintln(a.out, text.Indent(string(tb), "  "))
+	printComputedParameters(a.out, m)
+	printCompatibility(a.out, a.templateName, a.templateVersion, m)
 	return nil
 }
+
+func printMetadata(out io.Writer, m *config.ManifestFile) {
+	if m.Metadata.IsEmpty() {
+		return
+	}
+	fmt.Fprintln(out, "metadata:")
+	attributes := []struct {
+		name  string
+		value string
+	}{
+		{"name", m.Name},
+		{"description", m.Description},
+		{"license", m.License},
+		{"homepage", m.Homepage},
+		{"icon", m.Icon},
+		{"minLetsgopherVersion", m.MinLetsgopherVersion},
+		{"maxLetsgopherVersion", m.MaxLetsgopherVersion},
+	}
+	for _, a := range attributes {
+		if a.value != "" {
+			fmt.Fprintln(out, fmt.Sprintf("  %s: %q", a.name, a.value))
+		}
+	}
+	lists := []struct {
+		name   string
+		values []string
+	}{
+		{"authors", m.Authors},
+		{"tags", m.Tags},
+		{"keywords", m.Keywords},
+	}
+	for _, l := range lists {
+		if len(l.values) > 0 {
+			fmt.Fprintln(out, fmt.Sprintf("  %s: %s", l.name, quoteAll(l.values)))
+		}
+	}
+}
+
+// quoteAll renders a list of strings as YAML flow sequence.
+func quoteAll(values []string) string {
+	quoted := []string{}
+	for _, v := range values {
+		quoted = append(quoted, fmt.Sprintf("%q", v))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func printCompatibility(out io.Writer, name string, templateVersion string, m *config.ManifestFile) {
+	err := checkCompatibility(&config.Template{Name: name, Version: templateVersion}, m)
+	if err != nil {
+		fmt.Fprintf(out, "warning: %s\n", err)
+	}
+}
+
+func printComputedParameters(out io.Writer, m *config.ManifestFile) {
+	computed := m.ComputedParameters()
+	if len(computed) == 0 {
+		return
+	}
+	fmt.Fprintln(out, "computed:")
+	for _, p := range computed {
+		fmt.Fprintln(out, fmt.Sprintf("  - name: %q", p.Name))
+		fmt.Fprintln(out, fmt.Sprintf("    expression: %q", p.Computed))
+	}
+}
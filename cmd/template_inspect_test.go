@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/template/archive"
 	"github.com/bmuschko/letsgopher/template/storage"
+	"github.com/bmuschko/letsgopher/testhelper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"os"
@@ -84,15 +86,15 @@ templates:
 	defer f.Close()
 	aM.On("LoadManifestFile", archiveFile).Return([]byte(`version: "1.0.0"
 parameters:
-	- name: "module"
-prompt: "Please provide a module name"
-	type: "string"
-description: "The module name is used in the go.mod file"
-	- name: "message"
-prompt: "Please select a message"
-	type: "string"
-enum: ["Hello World!", "Let's get started", "This is just the beginning"]
-	description: "The message to be rendered when executing the program"`), nil)
+  - name: "module"
+    prompt: "Please provide a module name"
+    type: "string"
+    description: "The module name is used in the go.mod file"
+  - name: "message"
+    prompt: "Please select a message"
+    type: "string"
+    enum: ["Hello World!", "Let's get started", "This is just the beginning"]
+    description: "The message to be rendered when executing the program"`), nil)
 	err = templateInspect.run()
 
 	aM.AssertExpectations(t)
@@ -103,24 +105,104 @@ enum: ["Hello World!", "Let's get started", "This is just the beginning"]
 manifest:
   version: "1.0.0"
   parameters:
-  	- name: "module"
-  prompt: "Please provide a module name"
-  	type: "string"
-  description: "The module name is used in the go.mod file"
-  	- name: "message"
-  prompt: "Please select a message"
-  	type: "string"
-  enum: ["Hello World!", "Let's get started", "This is just the beginning"]
-  	description: "The message to be rendered when executing the program"
+    - name: "module"
+      prompt: "Please provide a module name"
+      type: "string"
+      description: "The module name is used in the go.mod file"
+    - name: "message"
+      prompt: "Please select a message"
+      type: "string"
+      enum: ["Hello World!", "Let's get started", "This is just the beginning"]
+      description: "The message to be rendered when executing the program"
 `, b.String())
 }
 
+func TestInspectTemplateWithInvalidManifest(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveFile := writeHelloWorldTemplatesFile(t, tmpHome)
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	templateInspect := &templateInspectCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}
+	aM.On("LoadManifestFile", archiveFile).Return([]byte("version: \"1.0.0\"\nparameters:\n\t- name: \"module\""), nil)
+	err := templateInspect.run()
+
+	aM.AssertExpectations(t)
+	assert.NotNil(t, err)
+	assert.Equal(t, "failed to parse manifest.yaml of template \"hello-world\" with version \"1.0.0\": error converting YAML to JSON: yaml: line 3: found character that cannot start any token", err.Error())
+	assert.Empty(t, b.String())
+}
+
+func TestInspectTemplateWithComputedParameters(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	templateInspect := &templateInspectCmd{
+		templateName:    "hello-world",
+		templateVersion: "1.0.0",
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}
+	archiveFile := fmt.Sprintf("%s/archive/hello-world-1.0.0.zip", tmpHome)
+	testhelper.WriteFile(t, storage.Home(tmpHome).TemplatesFile(), fmt.Sprintf(`generated: "2019-03-15T16:31:57.232715-06:00"
+templates:
+- archivePath: %s
+  name: hello-world
+  version: 1.0.0`, archiveFile), 0644)
+	aM.On("LoadManifestFile", archiveFile).Return([]byte(`version: "1.0.0"
+parameters:
+  - name: "name"
+    prompt: "Please provide a name"
+    type: "string"
+  - name: "package"
+    type: "string"
+    computed: "packageName .name"`), nil)
+	err := templateInspect.run()
+
+	aM.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, `template:
+  name: "hello-world"
+  version: "1.0.0"
+manifest:
+  version: "1.0.0"
+  parameters:
+    - name: "name"
+      prompt: "Please provide a name"
+      type: "string"
+    - name: "package"
+      type: "string"
+      computed: "packageName .name"
+computed:
+  - name: "package"
+    expression: "packageName .name"
+`, b.String())
+}
+
+// noPostProcessors is the post-processors argument of an extraction without post-processors.
+var noPostProcessors []archive.PostProcessor
+
 type ArchiverMock struct {
 	mock.Mock
 }
 
-func (a *ArchiverMock) Extract(archiveFile string, targetDir string, replacements map[string]interface{}) error {
-	args := a.Called(archiveFile, targetDir, replacements)
+func (a *ArchiverMock) ExtractLayers(archiveFiles []string, targetDir string, replacements map[string]interface{}, postProcessors ...archive.PostProcessor) error {
+	args := a.Called(archiveFiles, targetDir, replacements, postProcessors)
+	return args.Error(0)
+}
+
+func (a *ArchiverMock) CheckConflicts(archiveFiles []string, targetDir string, replacements map[string]interface{}) error {
+	args := a.Called(archiveFiles, targetDir, replacements)
 	return args.Error(0)
 }
 
@@ -128,3 +210,95 @@ func (a *ArchiverMock) LoadManifestFile(src string) ([]byte, error) {
 	args := a.Called(src)
 	return args.Get(0).([]byte), args.Error(1)
 }
+
+func (a *ArchiverMock) LoadFile(src string, name string) ([]byte, error) {
+	args := a.Called(src, name)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func TestInspectTemplateWithMetadata(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	templateInspect := &templateInspectCmd{
+		templateName:    "go-service",
+		templateVersion: "2.0.0",
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}
+	archiveFile := fmt.Sprintf("%s/archive/go-service-2.0.0.zip", tmpHome)
+	testhelper.WriteFile(t, storage.Home(tmpHome).TemplatesFile(), fmt.Sprintf(`generated: "2019-03-15T16:31:57.232715-06:00"
+templates:
+- archivePath: %s
+  name: go-service
+  version: 2.0.0`, archiveFile), 0644)
+	aM.On("LoadManifestFile", archiveFile).Return([]byte(`version: "2.0.0"
+name: "Go service"
+description: "A Go service exposing a REST API"
+authors: ["Jane Doe"]
+license: "Apache-2.0"
+tags: ["service", "rest"]`), nil)
+	err := templateInspect.run()
+
+	aM.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, `template:
+  name: "go-service"
+  version: "2.0.0"
+metadata:
+  name: "Go service"
+  description: "A Go service exposing a REST API"
+  license: "Apache-2.0"
+  authors: ["Jane Doe"]
+  tags: ["service", "rest"]
+manifest:
+  version: "2.0.0"
+  name: "Go service"
+  description: "A Go service exposing a REST API"
+  authors: ["Jane Doe"]
+  license: "Apache-2.0"
+  tags: ["service", "rest"]
+`, b.String())
+}
+
+func TestInspectIncompatibleTemplate(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	SetVersion("0.8.0")
+	defer SetVersion("")
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	templateInspect := &templateInspectCmd{
+		templateName:    "go-service",
+		templateVersion: "2.0.0",
+		out:             b,
+		home:            storage.Home(tmpHome),
+		archiver:        aM,
+	}
+	archiveFile := fmt.Sprintf("%s/archive/go-service-2.0.0.zip", tmpHome)
+	testhelper.WriteFile(t, storage.Home(tmpHome).TemplatesFile(), fmt.Sprintf(`generated: "2019-03-15T16:31:57.232715-06:00"
+templates:
+- archivePath: %s
+  name: go-service
+  version: 2.0.0`, archiveFile), 0644)
+	aM.On("LoadManifestFile", archiveFile).Return([]byte(`version: "2.0.0"
+maxLetsgopherVersion: "0.7.5"`), nil)
+	err := templateInspect.run()
+
+	aM.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, `template:
+  name: "go-service"
+  version: "2.0.0"
+metadata:
+  maxLetsgopherVersion: "0.7.5"
+manifest:
+  version: "2.0.0"
+  maxLetsgopherVersion: "0.7.5"
+warning: template "go-service" with version "2.0.0" is incompatible: letsgopher 0.7.5 is supported at most but this is letsgopher 0.8.0, please use a different version of the template
+`, b.String())
+}
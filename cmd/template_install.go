@@ -4,12 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"github.com/blang/semver"
+	"github.com/bmuschko/letsgopher/template/archive"
 	"github.com/bmuschko/letsgopher/template/config"
 	"github.com/bmuschko/letsgopher/template/download"
 	"github.com/bmuschko/letsgopher/template/environment"
 	"github.com/bmuschko/letsgopher/template/storage"
 	"github.com/spf13/cobra"
 	"io"
+	"os"
 	"strings"
 )
 
@@ -19,6 +21,7 @@ type templateInstallCmd struct {
 	out          io.Writer
 	home         storage.Home
 	downloader   download.Downloader
+	archiver     archive.Archiver
 }
 
 func newTemplateInstallCmd(out io.Writer) *cobra.Command {
@@ -36,6 +39,7 @@ func newTemplateInstallCmd(out io.Writer) *cobra.Command {
 			install.templateName = args[1]
 			install.home = environment.Settings.Home
 			install.downloader = &download.TemplateDownloader{Home: environment.Settings.Home, Getter: download.NewHTTPGetter()}
+			install.archiver = &archive.ZIPArchiver{}
 			return install.run()
 		},
 	}
@@ -53,13 +57,32 @@ func (c *templateInstallCmd) run() error {
 		return err
 	}
 
-	if err := addTemplate(c.templateName, templateVersion, templateZIP, c.home); err != nil {
+	err = checkArchiveCompatibility(c.archiver, &config.Template{Name: c.templateName, Version: templateVersion}, templateZIP)
+	if err != nil {
+		os.Remove(templateZIP)
+		return err
+	}
+
+	if err := addTemplate(c.templateName, templateVersion, templateZIP, c.templateURL, c.home); err != nil {
 		return err
 	}
 	fmt.Fprintf(c.out, "%q has been added to your templates\n", c.templateName)
 	return nil
 }
 
+// checkArchiveCompatibility verifies that a downloaded template archive is compatible with the running letsgopher version before it is installed.
+func checkArchiveCompatibility(archiver archive.Archiver, template *config.Template, templateZIP string) error {
+	tb, err := archiver.LoadManifestFile(templateZIP)
+	if err != nil {
+		return err
+	}
+	m, err := config.LoadManifestData(tb)
+	if err != nil {
+		return err
+	}
+	return checkCompatibility(template, m)
+}
+
 func extractTemplateVersion(url string) (string, error) {
 	lastSlash := strings.LastIndex(url, "/")
 	lastDot := strings.LastIndex(url, ".")
@@ -80,7 +103,7 @@ func extractTemplateVersion(url string) (string, error) {
 	return parsedVersion.String(), nil
 }
 
-func addTemplate(name string, version string, templateZIP string, home storage.Home) error {
+func addTemplate(name string, version string, templateZIP string, sourceURL string, home storage.Home) error {
 	f, err := config.LoadTemplatesFile(home.TemplatesFile())
 	if err != nil {
 		return err
@@ -94,6 +117,7 @@ func addTemplate(name string, version string, templateZIP string, home storage.H
 		Name:        name,
 		Version:     version,
 		ArchivePath: templateZIP,
+		SourceURL:   sourceURL,
 	}
 	f.Update(&c)
 
@@ -8,6 +8,7 @@ import (
 	"github.com/bmuschko/letsgopher/testhelper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"path/filepath"
 	"testing"
 )
 
@@ -21,25 +22,30 @@ templates: []`, 0644)
 
 	b := bytes.NewBuffer(nil)
 	dM := new(DownloaderMock)
+	aM := new(ArchiverMock)
 	templateInstall := &templateInstallCmd{
 		templateURL:  "http://my.repo.com/hello-world-1.0.0.zip",
 		templateName: "new-project",
 		out:          b,
 		home:         storage.Home(tmpHome),
 		downloader:   dM,
+		archiver:     aM,
 	}
 	dM.On("Download", "http://my.repo.com/hello-world-1.0.0.zip").Return("/my/path/new-project/hello-world-1.0.0.zip", nil)
+	aM.On("LoadManifestFile", "/my/path/new-project/hello-world-1.0.0.zip").Return([]byte("version: \"1.0.0\""), nil)
 	err := templateInstall.run()
 
 	templates := testhelper.ReadFile(t, f)
 
 	dM.AssertExpectations(t)
+	aM.AssertExpectations(t)
 	assert.Nil(t, err)
 	assert.Equal(t, "\"new-project\" has been added to your templates\n", b.String())
 	assert.Equal(t, `generated: "2019-03-21T08:49:27.10175-06:00"
 templates:
 - archivePath: /my/path/new-project/hello-world-1.0.0.zip
   name: new-project
+  sourceURL: http://my.repo.com/hello-world-1.0.0.zip
   version: 1.0.0
 `, templates)
 }
@@ -58,17 +64,21 @@ templates:
 
 	b := bytes.NewBuffer(nil)
 	dM := new(DownloaderMock)
+	aM := new(ArchiverMock)
 	templateInstall := &templateInstallCmd{
 		templateURL:  "http://my.repo.com/hello-world-1.0.0.zip",
 		templateName: "new-project",
 		out:          b,
 		home:         storage.Home(tmpHome),
 		downloader:   dM,
+		archiver:     aM,
 	}
 	dM.On("Download", "http://my.repo.com/hello-world-1.0.0.zip").Return("/my/path/new-project/hello-world-1.0.0.zip", nil)
+	aM.On("LoadManifestFile", "/my/path/new-project/hello-world-1.0.0.zip").Return([]byte("version: \"1.0.0\""), nil)
 	err := templateInstall.run()
 
 	dM.AssertExpectations(t)
+	aM.AssertExpectations(t)
 	assert.NotNil(t, err)
 	assert.Equal(t, "template with name \"new-project\" already exists, please specify a different name", err.Error())
 }
@@ -99,6 +109,43 @@ templates: []`
 	assert.Equal(t, "expected", err.Error())
 }
 
+func TestInstallIncompatibleTemplate(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	templatesContent := `generated: "2019-03-21T08:49:27.10175-06:00"
+templates: []`
+	f := storage.Home(tmpHome).TemplatesFile()
+	testhelper.WriteFile(t, f, templatesContent, 0644)
+	archiveZip := filepath.Join(tmpHome, "hello-world-1.0.0.zip")
+	testhelper.WriteFile(t, archiveZip, "", 0644)
+
+	SetVersion("0.4.0")
+	defer SetVersion("")
+	b := bytes.NewBuffer(nil)
+	dM := new(DownloaderMock)
+	aM := new(ArchiverMock)
+	templateInstall := &templateInstallCmd{
+		templateURL:  "http://my.repo.com/hello-world-1.0.0.zip",
+		templateName: "new-project",
+		out:          b,
+		home:         storage.Home(tmpHome),
+		downloader:   dM,
+		archiver:     aM,
+	}
+	dM.On("Download", "http://my.repo.com/hello-world-1.0.0.zip").Return(archiveZip, nil)
+	aM.On("LoadManifestFile", archiveZip).Return([]byte(`version: "2.0.0"
+minLetsgopherVersion: "0.5.0"`), nil)
+	err := templateInstall.run()
+
+	dM.AssertExpectations(t)
+	aM.AssertExpectations(t)
+	assert.NotNil(t, err)
+	assert.Equal(t, "template \"new-project\" with version \"1.0.0\" is incompatible: letsgopher 0.5.0 or higher is required but this is letsgopher 0.4.0, please upgrade letsgopher", err.Error())
+	assert.Equal(t, templatesContent, testhelper.ReadFile(t, f))
+	testhelper.FileNotExists(t, archiveZip)
+}
+
 type DownloaderMock struct {
 	mock.Mock
 }
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/bmuschko/letsgopher/template/generate"
+	"github.com/bmuschko/letsgopher/template/lint"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+)
+
+const (
+	humanOutput = "human"
+	jsonOutput  = "json"
+	sarifOutput = "sarif"
+)
+
+type templateLintCmd struct {
+	source string
+	output string
+	out    io.Writer
+}
+
+func newTemplateLintCmd(out io.Writer) *cobra.Command {
+	lint := &templateLintCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "lint [dir|archive]",
+		Short: "checks a template directory or archive for problems",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "the template directory or archive"); err != nil {
+				return err
+			}
+
+			lint.source = args[0]
+			return lint.run()
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&lint.output, "output", humanOutput, "format of the reported problems: human, json or sarif")
+	return cmd
+}
+
+func (c *templateLintCmd) run() error {
+	if c.output != humanOutput && c.output != jsonOutput && c.output != sarifOutput {
+		return fmt.Errorf("unknown output format %q, use %s, %s or %s", c.output, humanOutput, jsonOutput, sarifOutput)
+	}
+	files, err := loadTemplateFiles(c.source)
+	if err != nil {
+		return err
+	}
+
+	linter := &lint.Linter{ComponentParams: generate.ModuleParams}
+	findings := linter.Lint(files)
+	switch c.output {
+	case jsonOutput:
+		err = lint.WriteJSON(c.out, findings)
+	case sarifOutput:
+		err = lint.WriteSARIF(c.out, findings, version)
+	default:
+		err = lint.WriteText(c.out, findings)
+	}
+	if err != nil {
+		return err
+	}
+
+	if lint.HasErrors(findings) {
+		return fmt.Errorf("template %q has %d error(s)", c.source, lint.Count(findings, lint.Error))
+	}
+	return nil
+}
+
+// loadTemplateFiles loads the files of a template from a directory or a ZIP archive.
+func loadTemplateFiles(source string) ([]*lint.File, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return lint.LoadDir(source)
+	}
+	return lint.LoadZIP(source)
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"bytes"
+	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/testhelper"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func TestLintTemplateDirectory(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	testhelper.WriteFile(t, filepath.Join(tmpDir, "manifest.yaml"), `version: "1.0.0"
+parameters:
+  - name: "name"
+    type: "string"`, 0644)
+	testhelper.WriteFile(t, filepath.Join(tmpDir, "go.mod"), "module {{ .modulePath }}/{{ .name }}", 0644)
+	b := bytes.NewBuffer(nil)
+	lint := &templateLintCmd{
+		source: tmpDir,
+		output: humanOutput,
+		out:    b,
+	}
+	err := lint.run()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "go.mod:1: warning: parameter \"modulePath\" is only provided by the add command and renders empty when creating a project [component-parameter]\n1 problem(s): 0 error(s), 1 warning(s)\n", b.String())
+}
+
+func TestLintTemplateArchiveWithErrors(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := filepath.Join(tmpDir, "hello-world-1.0.0.zip")
+	testhelper.CreateZip(t, archiveZip, []testhelper.TestFile{
+		{Name: "manifest.yaml", Content: "version: \"1.0.0\""},
+		{Name: "main.go", Content: "package {{ .name }}"},
+	})
+	b := bytes.NewBuffer(nil)
+	lint := &templateLintCmd{
+		source: archiveZip,
+		output: jsonOutput,
+		out:    b,
+	}
+	err := lint.run()
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "template \""+archiveZip+"\" has 1 error(s)", err.Error())
+	assert.JSONEq(t, `{"findings": [
+  {"rule": "undeclared-parameter", "severity": "error", "file": "main.go", "line": 1, "message": "parameter \"name\" is not declared in the manifest"}
+]}`, b.String())
+}
+
+func TestLintTemplateWithUnknownOutput(t *testing.T) {
+	lint := &templateLintCmd{
+		source: "hello-world",
+		output: "xml",
+		out:    bytes.NewBuffer(nil),
+	}
+	err := lint.run()
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "unknown output format \"xml\", use human, json or sarif", err.Error())
+}
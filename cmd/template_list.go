@@ -3,17 +3,20 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"github.com/bmuschko/letsgopher/template/archive"
 	"github.com/bmuschko/letsgopher/template/config"
 	"github.com/bmuschko/letsgopher/template/environment"
 	"github.com/bmuschko/letsgopher/template/storage"
 	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 	"io"
+	"strings"
 )
 
 type templateListCmd struct {
-	out  io.Writer
-	home storage.Home
+	out      io.Writer
+	home     storage.Home
+	archiver archive.Archiver
 }
 
 func newTemplateListCmd(out io.Writer) *cobra.Command {
@@ -24,6 +27,7 @@ func newTemplateListCmd(out io.Writer) *cobra.Command {
 		Short: "list templates",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			list.home = environment.Settings.Home
+			list.archiver = &archive.ZIPArchiver{}
 			return list.run()
 		},
 	}
@@ -40,10 +44,25 @@ func (a *templateListCmd) run() error {
 		return errors.New("no templates installed")
 	}
 	table := uitable.New()
-	table.AddRow("NAME", "VERSION", "ARCHIVE PATH")
+	table.AddRow("NAME", "VERSION", "DESCRIPTION", "TAGS", "ARCHIVE PATH")
 	for _, te := range f.Templates {
-		table.AddRow(te.Name, te.Version, te.ArchivePath)
+		m := a.loadMetadata(te)
+		table.AddRow(te.Name, te.Version, m.Description, strings.Join(m.Tags, ","), te.ArchivePath)
 	}
 	fmt.Fprintln(a.out, table)
 	return nil
 }
+
+// loadMetadata loads the metadata declared by the manifest of an installed template.
+// Templates whose manifest cannot be loaded are listed without metadata.
+func (a *templateListCmd) loadMetadata(template *config.Template) config.Metadata {
+	tb, err := a.archiver.LoadManifestFile(template.ArchivePath)
+	if err != nil {
+		return config.Metadata{}
+	}
+	m, err := config.LoadManifestData(tb)
+	if err != nil {
+		return config.Metadata{}
+	}
+	return m.Metadata
+}
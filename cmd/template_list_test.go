@@ -3,7 +3,9 @@ package cmd
 import (
 	"bytes"
 	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/template/archive"
 	"github.com/bmuschko/letsgopher/template/storage"
+	"github.com/bmuschko/letsgopher/testhelper"
 	"github.com/stretchr/testify/assert"
 	"os"
 	"testing"
@@ -56,8 +58,9 @@ func TestPopulatedTemplateList(t *testing.T) {
 
 	b := bytes.NewBuffer(nil)
 	templateList := &templateListCmd{
-		out:  b,
-		home: storage.Home(tmpHome),
+		out:      b,
+		home:     storage.Home(tmpHome),
+		archiver: &archive.ZIPArchiver{},
 	}
 	templatesFile := storage.Home(tmpHome).TemplatesFile()
 	f, err := os.Create(templatesFile)
@@ -76,7 +79,35 @@ templates:
 	err = templateList.run()
 
 	assert.Nil(t, err)
-	assert.Equal(t, `NAME       	VERSION	ARCHIVE PATH                                             
-hello-world	0.2.0  	/Users/bmuschko/.letsgopher/archive/hello-world-0.2.0.zip
+	assert.Equal(t, `NAME       	VERSION	DESCRIPTION	TAGS	ARCHIVE PATH                                             
+hello-world	0.2.0  	           	    	/Users/bmuschko/.letsgopher/archive/hello-world-0.2.0.zip
+`, b.String())
+}
+
+func TestTemplateListWithMetadata(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	b := bytes.NewBuffer(nil)
+	aM := new(ArchiverMock)
+	templateList := &templateListCmd{
+		out:      b,
+		home:     storage.Home(tmpHome),
+		archiver: aM,
+	}
+	testhelper.WriteFile(t, storage.Home(tmpHome).TemplatesFile(), `generated: "2019-03-15T16:31:57.232715-06:00"
+templates:
+- archivePath: /archive/go-service-2.0.0.zip
+  name: go-service
+  version: 2.0.0`, 0644)
+	aM.On("LoadManifestFile", "/archive/go-service-2.0.0.zip").Return([]byte(`version: "2.0.0"
+description: "A Go service"
+tags: ["service", "rest"]`), nil)
+	err := templateList.run()
+
+	aM.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, `NAME      	VERSION	DESCRIPTION 	TAGS        	ARCHIVE PATH                 
+go-service	2.0.0  	A Go service	service,rest	/archive/go-service-2.0.0.zip
 `, b.String())
 }
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/bmuschko/letsgopher/template/builtin"
+	"github.com/bmuschko/letsgopher/template/prompt"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"regexp"
+)
+
+var templateNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+type templateNewCmd struct {
+	templateName string
+	targetDir    string
+	params       []string
+	out          io.Writer
+	prompter     prompt.Prompter
+	lookupEnv    func(key string) (string, bool)
+}
+
+func newTemplateNewCmd(out io.Writer) *cobra.Command {
+	n := &templateNewCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "new [name] [target-dir]",
+		Short: "generates the skeleton of a new template",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 && len(args) != 2 {
+				return fmt.Errorf("this command needs 1 or 2 arguments: the template name, optionally the target directory")
+			}
+
+			n.templateName = args[0]
+			n.targetDir = args[0]
+			if len(args) == 2 {
+				n.targetDir = args[1]
+			}
+			n.prompter = &prompt.InteractivePrompter{}
+			n.lookupEnv = os.LookupEnv
+			return n.run()
+		},
+	}
+
+	cmd.PersistentFlags().StringSliceVar(&n.params, "param", []string{}, "parameter defined as key/value pair separated by = character, i.e. description or author")
+	return cmd
+}
+
+func (c *templateNewCmd) run() error {
+	if !templateNamePattern.MatchString(c.templateName) {
+		return fmt.Errorf("template name %q may only contain letters, digits, dots, dashes and underscores", c.templateName)
+	}
+	if _, err := os.Stat(c.targetDir); err == nil {
+		return fmt.Errorf("target directory %q already exists", c.targetDir)
+	}
+
+	m, err := builtin.Scaffold.LoadManifest()
+	if err != nil {
+		return err
+	}
+	resolver, err := newResolver(c.params, "", c.lookupEnv, c.prompter)
+	if err != nil {
+		return err
+	}
+	resolver.Implicit = map[string]interface{}{"name": c.templateName}
+	r, err := resolver.Resolve(m.Parameters)
+	if err != nil {
+		return err
+	}
+
+	err = builtin.Scaffold.Generate(c.targetDir, r)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "created template %q at %q\n", c.templateName, c.targetDir)
+	return nil
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/bmuschko/letsgopher/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewTemplate(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	targetDir := filepath.Join(tmpDir, "hello-world")
+	b := bytes.NewBuffer(nil)
+	pM := new(PrompterMock)
+	templateNew := &templateNewCmd{
+		templateName: "hello-world",
+		targetDir:    targetDir,
+		params:       []string{"description=A friendly greeter"},
+		out:          b,
+		prompter:     pM,
+	}
+	pM.On("Prompt", mock.MatchedBy(func(p *config.Parameter) bool { return p.Name == "author" }), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(map[string]interface{})["author"] = "Jane Doe"
+	})
+	err := templateNew.run()
+
+	pM.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, "created template \"hello-world\" at \""+targetDir+"\"\n", b.String())
+	manifest, err := ioutil.ReadFile(filepath.Join(targetDir, "manifest.yaml"))
+	assert.Nil(t, err)
+	m, err := config.LoadManifestData(manifest)
+	assert.Nil(t, err)
+	assert.Equal(t, config.Metadata{Name: "hello-world", Description: "A friendly greeter", Authors: []string{"Jane Doe"}, Tags: []string{"example"}}, m.Metadata)
+	assert.Contains(t, testhelper.ReadFile(t, filepath.Join(targetDir, ".letsgopherignore")), "/tests/\n")
+	assert.FileExists(t, filepath.Join(targetDir, "tests", "default", "answers.yaml"))
+	assert.FileExists(t, filepath.Join(targetDir, "tests", "default", "expected", "main.go"))
+}
+
+func TestNewTemplateWithInvalidTarget(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	targets := []struct {
+		name      string
+		targetDir string
+		expected  string
+	}{
+		{"hello world", filepath.Join(tmpDir, "hello-world"), "template name \"hello world\" may only contain letters, digits, dots, dashes and underscores"},
+		{"hello-world", tmpDir, "target directory \"" + tmpDir + "\" already exists"},
+	}
+
+	for _, target := range targets {
+		t.Run(target.name, func(t *testing.T) {
+			templateNew := &templateNewCmd{
+				templateName: target.name,
+				targetDir:    target.targetDir,
+				out:          bytes.NewBuffer(nil),
+			}
+			err := templateNew.run()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, target.expected, err.Error())
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/blang/semver"
+	"github.com/bmuschko/letsgopher/template/archive"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/spf13/cobra"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+type templatePackageCmd struct {
+	dir             string
+	templateVersion string
+	destination     string
+	format          string
+	out             io.Writer
+}
+
+func newTemplatePackageCmd(out io.Writer) *cobra.Command {
+	pkg := &templatePackageCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "package [dir]",
+		Short: "packages a template directory into a versioned archive",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "the template directory"); err != nil {
+				return err
+			}
+
+			pkg.dir = args[0]
+			return pkg.run()
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&pkg.templateVersion, "version", "", "semantic version of the packaged template")
+	cmd.PersistentFlags().StringVar(&pkg.destination, "destination", ".", "directory the archive and its checksum file are written to")
+	cmd.PersistentFlags().StringVar(&pkg.format, "format", string(archive.ZIPFormat), "archive format: zip or tar.gz")
+	return cmd
+}
+
+func (c *templatePackageCmd) run() error {
+	format, err := archive.ParsePackageFormat(c.format)
+	if err != nil {
+		return err
+	}
+	if c.templateVersion == "" {
+		return fmt.Errorf("the version of the template needs to be provided with --version")
+	}
+	v, err := semver.Make(c.templateVersion)
+	if err != nil {
+		return fmt.Errorf("template version %q needs to follow the semantic versioning scheme: %s", c.templateVersion, err)
+	}
+	name, err := c.templateName()
+	if err != nil {
+		return err
+	}
+
+	archiveFile := filepath.Join(c.destination, fmt.Sprintf("%s-%s.%s", name, v, format))
+	err = archive.Package(c.dir, archiveFile, format)
+	if err != nil {
+		return err
+	}
+	checksumFile, err := archive.WriteChecksumFile(archiveFile)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "packaged template %q with version %q to %q\n", name, v.String(), archiveFile)
+	fmt.Fprintf(c.out, "wrote checksum to %q\n", checksumFile)
+	return nil
+}
+
+// templateName validates the manifest of the template directory and determines the name of the template.
+// The name declared by the manifest takes precedence over the name of the directory.
+func (c *templatePackageCmd) templateName() (string, error) {
+	manifestFile := filepath.Join(c.dir, config.ManifestFileName)
+	b, err := ioutil.ReadFile(manifestFile)
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("template directory %q needs to contain a %s file", c.dir, config.ManifestFileName)
+	}
+	if err != nil {
+		return "", err
+	}
+	m, err := config.LoadManifestData(b)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s: %s", manifestFile, err)
+	}
+	err = config.ValidateManifest(m)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %s", manifestFile, err)
+	}
+
+	if m.Metadata.Name != "" {
+		return m.Metadata.Name, nil
+	}
+	dir, err := filepath.Abs(c.dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Base(dir), nil
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bytes"
+	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/template/archive"
+	"github.com/bmuschko/letsgopher/testhelper"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPackageTemplateNamedByManifest(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	destination := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	testhelper.WriteFile(t, filepath.Join(tmpDir, "manifest.yaml"), "version: \"2.0.0\"\nname: \"hello-world\"", 0644)
+	testhelper.WriteFile(t, filepath.Join(tmpDir, "main.go"), "package main", 0644)
+	b := bytes.NewBuffer(nil)
+	pkg := &templatePackageCmd{
+		dir:             tmpDir,
+		templateVersion: "1.2.0",
+		destination:     destination,
+		format:          "zip",
+		out:             b,
+	}
+	err := pkg.run()
+
+	archiveZip := filepath.Join(destination, "hello-world-1.2.0.zip")
+	assert.Nil(t, err)
+	assert.Equal(t, "packaged template \"hello-world\" with version \"1.2.0\" to \""+archiveZip+"\"\nwrote checksum to \""+archiveZip+".sha256\"\n", b.String())
+	digest, err := archive.Digest(archiveZip)
+	assert.Nil(t, err)
+	assert.Equal(t, strings.TrimPrefix(digest, "sha256:")+"  hello-world-1.2.0.zip\n", testhelper.ReadFile(t, archiveZip+".sha256"))
+	manifest, err := (&archive.ZIPArchiver{}).LoadManifestFile(archiveZip)
+	assert.Nil(t, err)
+	assert.Equal(t, "version: \"2.0.0\"\nname: \"hello-world\"", string(manifest))
+}
+
+func TestPackageTemplateNamedByDirectory(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	dir := filepath.Join(tmpDir, "basic")
+	assert.Nil(t, os.MkdirAll(dir, 0755))
+	testhelper.WriteFile(t, filepath.Join(dir, "manifest.yaml"), "version: \"1.0.0\"", 0644)
+	pkg := &templatePackageCmd{
+		dir:             dir,
+		templateVersion: "0.1.0",
+		destination:     tmpDir,
+		format:          "tar.gz",
+		out:             bytes.NewBuffer(nil),
+	}
+	err := pkg.run()
+
+	assert.Nil(t, err)
+	assert.FileExists(t, filepath.Join(tmpDir, "basic-0.1.0.tar.gz"))
+	assert.FileExists(t, filepath.Join(tmpDir, "basic-0.1.0.tar.gz.sha256"))
+}
+
+func TestPackageInvalidTemplate(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	invalidDir := filepath.Join(tmpDir, "invalid")
+	assert.Nil(t, os.MkdirAll(invalidDir, 0755))
+	testhelper.WriteFile(t, filepath.Join(invalidDir, "manifest.yaml"), "version: \"\"", 0644)
+	templates := []struct {
+		name     string
+		dir      string
+		version  string
+		format   string
+		expected string
+	}{
+		{"missing version", tmpDir, "", "zip", "the version of the template needs to be provided with --version"},
+		{"invalid version", tmpDir, "1.0", "zip", "template version \"1.0\" needs to follow the semantic versioning scheme: No Major.Minor.Patch elements found"},
+		{"unknown format", tmpDir, "1.0.0", "rar", "unknown package format \"rar\", expected one of [zip, tar.gz]"},
+		{"missing manifest", tmpDir, "1.0.0", "zip", "template directory \"" + tmpDir + "\" needs to contain a manifest.yaml file"},
+		{"invalid manifest", invalidDir, "1.0.0", "zip", "invalid " + filepath.Join(invalidDir, "manifest.yaml") + ": /version (line 1): manifest file needs to provide a version"},
+	}
+
+	for _, tt := range templates {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := &templatePackageCmd{
+				dir:             tt.dir,
+				templateVersion: tt.version,
+				destination:     tmpDir,
+				format:          tt.format,
+				out:             bytes.NewBuffer(nil),
+			}
+			err := pkg.run()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, tt.expected, err.Error())
+		})
+	}
+}
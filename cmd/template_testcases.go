@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/bmuschko/letsgopher/template/templatetest"
+	"github.com/spf13/cobra"
+	"io"
+	"strings"
+)
+
+type templateTestCmd struct {
+	dir    string
+	update bool
+	out    io.Writer
+}
+
+func newTemplateTestCmd(out io.Writer) *cobra.Command {
+	test := &templateTestCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "test [dir]",
+		Short: "renders the test cases of a template directory and compares them with the expected files",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "the template directory"); err != nil {
+				return err
+			}
+
+			test.dir = args[0]
+			return test.run()
+		},
+	}
+
+	cmd.PersistentFlags().BoolVar(&test.update, "update", false, "rewrite the expected files of the test cases with the generated files")
+	return cmd
+}
+
+func (c *templateTestCmd) run() error {
+	harness := &templatetest.Harness{Update: c.update}
+	results, err := harness.Run(c.dir)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			failed++
+			fmt.Fprintf(c.out, "FAIL %s\n    %s\n", r.Case.Name, r.Err)
+		case r.Updated:
+			fmt.Fprintf(c.out, "UPDATED %s (%d file(s))\n", r.Case.Name, len(r.Mismatches))
+		case len(r.Mismatches) > 0:
+			failed++
+			fmt.Fprintf(c.out, "FAIL %s\n", r.Case.Name)
+			for _, m := range r.Mismatches {
+				fmt.Fprintf(c.out, "    %s %s\n", m.File, m.Kind)
+				fmt.Fprint(c.out, indent(m.Diff, "    "))
+			}
+		default:
+			fmt.Fprintf(c.out, "PASS %s\n", r.Case.Name)
+		}
+	}
+	fmt.Fprintf(c.out, "%d test case(s): %d passed, %d failed\n", len(results), len(results)-failed, failed)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d test case(s) of template %q failed", failed, len(results), c.dir)
+	}
+	return nil
+}
+
+func indent(s string, prefix string) string {
+	lines := strings.SplitAfter(s, "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = prefix + l
+		}
+	}
+	return strings.Join(lines, "")
+}
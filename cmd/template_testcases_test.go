@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/testhelper"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func TestTemplateTestPassingCases(t *testing.T) {
+	dir := writeTestTemplate(t, map[string]string{
+		"tests/default/expected/main.go": "package hello\n",
+		"tests/orders/answers.yaml":      "name: \"orders\"\n",
+		"tests/orders/expected/main.go":  "package orders\n",
+	})
+	defer filet.CleanUp(t)
+
+	b := bytes.NewBuffer(nil)
+	templateTest := &templateTestCmd{dir: dir, out: b}
+	err := templateTest.run()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "PASS default\nPASS orders\n2 test case(s): 2 passed, 0 failed\n", b.String())
+}
+
+func TestTemplateTestFailingCases(t *testing.T) {
+	dir := writeTestTemplate(t, map[string]string{
+		"tests/default/expected/main.go": "package main\n",
+		"tests/invalid/answers.yaml":     "name: \"\"\n",
+	})
+	defer filet.CleanUp(t)
+
+	b := bytes.NewBuffer(nil)
+	templateTest := &templateTestCmd{dir: dir, out: b}
+	err := templateTest.run()
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "2 of 2 test case(s) of template \""+dir+"\" failed", err.Error())
+	assert.Equal(t, `FAIL default
+    main.go differs
+    --- expected/main.go
+    +++ generated/main.go
+    @@ -1 +1 @@
+    -package main
+    +package hello
+FAIL invalid
+    provided value for parameter "name" is invalid: value "" needs to be at least 1 characters long
+2 test case(s): 0 passed, 2 failed
+`, b.String())
+}
+
+func TestTemplateTestUpdatesExpectedFiles(t *testing.T) {
+	dir := writeTestTemplate(t, map[string]string{
+		"tests/default/expected/main.go": "package main\n",
+	})
+	defer filet.CleanUp(t)
+
+	b := bytes.NewBuffer(nil)
+	templateTest := &templateTestCmd{dir: dir, update: true, out: b}
+	err := templateTest.run()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "UPDATED default (1 file(s))\n1 test case(s): 1 passed, 0 failed\n", b.String())
+	assert.Equal(t, "package hello\n", testhelper.ReadFile(t, filepath.Join(dir, "tests", "default", "expected", "main.go")))
+}
+
+func TestTemplateTestWithoutTestCases(t *testing.T) {
+	dir := writeTestTemplate(t, map[string]string{})
+	defer filet.CleanUp(t)
+
+	b := bytes.NewBuffer(nil)
+	templateTest := &templateTestCmd{dir: dir, out: b}
+	err := templateTest.run()
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "template \""+dir+"\" needs to contain a tests directory with test cases", err.Error())
+	assert.Empty(t, b.String())
+}
+
+func writeTestTemplate(t *testing.T, files map[string]string) string {
+	dir := filet.TmpDir(t, "")
+	files["manifest.yaml"] = "version: \"1.0.0\"\nparameters:\n  - name: \"name\"\n    type: \"string\"\n    defaultValue: \"hello\"\n    minLength: 1\n"
+	files["main.go"] = "package {{ .name }}\n"
+	testhelper.WriteFiles(t, dir, files)
+	return dir
+}
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/bmuschko/letsgopher/template/archive"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/bmuschko/letsgopher/template/environment"
+	"github.com/bmuschko/letsgopher/template/generate"
+	"github.com/bmuschko/letsgopher/template/merge"
+	"github.com/bmuschko/letsgopher/template/param"
+	"github.com/bmuschko/letsgopher/template/prompt"
+	"github.com/bmuschko/letsgopher/template/storage"
+	"github.com/spf13/cobra"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const currentLabel = "current"
+
+type projectUpdateCmd struct {
+	projectDir  string
+	toVersion   string
+	params      []string
+	answersFile string
+	format      bool
+	formatSet   bool
+	out         io.Writer
+	home        storage.Home
+	archiver    archive.Archiver
+	prompter    prompt.Prompter
+	lookupEnv   func(key string) (string, bool)
+}
+
+func newUpdateCmd(out io.Writer) *cobra.Command {
+	update := &projectUpdateCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "update [dir]",
+		Short: "update a generated project to a different template version",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("this command accepts at most 1 argument: the project directory")
+			}
+			if update.toVersion == "" {
+				return errors.New("the template version to update to needs to be provided with --to")
+			}
+
+			update.projectDir = "."
+			if len(args) == 1 {
+				update.projectDir = args[0]
+			}
+			update.formatSet = cmd.Flags().Changed("format")
+			update.home = environment.Settings.Home
+			update.archiver = &archive.ZIPArchiver{Processor: &archive.TemplateProcessor{}}
+			update.prompter = &prompt.InteractivePrompter{}
+			update.lookupEnv = os.LookupEnv
+			return update.run()
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&update.toVersion, "to", "", "the template version to update the project to")
+	cmd.PersistentFlags().StringSliceVar(&update.params, "param", []string{}, "parameter defined as key/value pair separated by = character, repeat the key for list and map values")
+	cmd.PersistentFlags().StringVar(&update.answersFile, "answers", "", "YAML file providing parameter values by parameter name")
+	cmd.PersistentFlags().BoolVar(&update.format, "format", false, "format generated Go source files with gofmt, defaults to the option the project has been generated with")
+	return cmd
+}
+
+func (c *projectUpdateCmd) run() error {
+	provenance, err := loadProvenanceFile(c.projectDir)
+	if err != nil {
+		return err
+	}
+	if provenance.Template.Version == c.toVersion {
+		return fmt.Errorf("project has already been generated from version %q of template %q", c.toVersion, provenance.Template.Name)
+	}
+
+	f, err := config.LoadTemplatesFile(c.home.TemplatesFile())
+	if err != nil {
+		return err
+	}
+	from, err := recordedTemplate(f, provenance)
+	if err != nil {
+		return err
+	}
+	to, err := installedTemplate(f, provenance.Template.Name, c.toVersion)
+	if err != nil {
+		return err
+	}
+
+	fromArchives, fromManifest, err := loadTemplateChain(c.home, from, c.archiver)
+	if err != nil {
+		return err
+	}
+	toArchives, toManifest, err := loadTemplateChain(c.home, to, c.archiver)
+	if err != nil {
+		return err
+	}
+
+	toReplacements, fromReplacements, err := c.resolveParameters(provenance, fromManifest, toManifest)
+	if err != nil {
+		return err
+	}
+
+	tmpDir, err := ioutil.TempDir("", "letsgopher-update")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	fromDir := filepath.Join(tmpDir, from.Version)
+	toDir := filepath.Join(tmpDir, to.Version)
+	err = renderTemplate(c.archiver, fromArchives, fromManifest, fromReplacements, fromDir, provenance.Format)
+	if err != nil {
+		return err
+	}
+	format := recordedFormat(provenance, c.format, c.formatSet)
+	err = renderTemplate(c.archiver, toArchives, toManifest, toReplacements, toDir, format)
+	if err != nil {
+		return err
+	}
+
+	labels := merge.Labels{Ours: currentLabel, Base: from.Version, Theirs: to.Version}
+	changes, err := merge.Directories(fromDir, toDir, c.projectDir, labels)
+	if err != nil {
+		return err
+	}
+	// Dependencies are not regenerated, the provenance file keeps the recorded dependencies as they are.
+	err = writeProvenanceFile(to, c.projectDir, toManifest.Parameters, toReplacements, provenance.Dependencies, format)
+	if err != nil {
+		return err
+	}
+
+	printChanges(c.out, changes)
+	for _, d := range provenance.Dependencies {
+		fmt.Fprintf(c.out, "dependency %q with version %q in %q has not been updated\n", d.Name, d.Version, d.Directory)
+	}
+	fmt.Fprintf(c.out, "updated project at %q from version %q to %q\n", c.projectDir, from.Version, to.Version)
+	return nil
+}
+
+// recordedFormat determines whether to format generated Go source files. The project is formatted the way it has been generated unless the format option is set explicitly.
+func recordedFormat(provenance *config.ProvenanceFile, format bool, formatSet bool) bool {
+	if formatSet {
+		return format
+	}
+	return provenance.Format
+}
+
+func installedTemplate(f *config.TemplatesFile, name string, version string) (*config.Template, error) {
+	template := f.Get(name, version)
+	if template == nil {
+		return nil, fmt.Errorf("template with name %q and version %q hasn't been installed", name, version)
+	}
+	return template, nil
+}
+
+func loadProvenanceFile(projectDir string) (*config.ProvenanceFile, error) {
+	provenance, err := config.LoadProvenanceFile(filepath.Join(projectDir, config.ProvenanceFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load provenance file of project: %s", err)
+	}
+	return provenance, nil
+}
+
+// recordedTemplate looks up the installed template a project has been generated from.
+// The archive needs to match the digest recorded in the provenance file to reproduce the project faithfully.
+func recordedTemplate(f *config.TemplatesFile, provenance *config.ProvenanceFile) (*config.Template, error) {
+	template, err := installedTemplate(f, provenance.Template.Name, provenance.Template.Version)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := archive.Digest(template.ArchivePath)
+	if err != nil {
+		return nil, err
+	}
+	if provenance.Template.Digest != "" && digest != provenance.Template.Digest {
+		return nil, fmt.Errorf("installed archive of template %q with version %q differs from the archive the project has been generated from", template.Name, template.Version)
+	}
+	return template, nil
+}
+
+// resolveParameters determines the parameter values for both template versions.
+// The values recorded in the provenance file take the place of an answers file, so that only new parameters are requested.
+// The previous version is rendered with the recorded values to reproduce the project as it has been generated.
+func (c *projectUpdateCmd) resolveParameters(provenance *config.ProvenanceFile, fromManifest *config.ManifestFile, toManifest *config.ManifestFile) (map[string]interface{}, map[string]interface{}, error) {
+	recorded := provenance.Answers()
+	resolver, err := newResolver(c.params, c.answersFile, c.lookupEnv, c.prompter)
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, v := range recorded {
+		if _, exists := resolver.Answers[k]; !exists {
+			resolver.Answers[k] = v
+		}
+	}
+	toReplacements, err := resolver.Resolve(toManifest.Parameters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	previous := make(map[string]string)
+	for k, v := range toReplacements {
+		previous[k] = config.FormatValue(v)
+	}
+	for k, v := range recorded {
+		previous[k] = v
+	}
+	fromResolver := &param.Resolver{Answers: previous, Prompter: c.prompter}
+	fromReplacements, err := fromResolver.Resolve(fromManifest.Parameters)
+	if err != nil {
+		return nil, nil, err
+	}
+	return toReplacements, fromReplacements, nil
+}
+
+// renderTemplate generates the files of a template and the templates it extends into a directory.
+func renderTemplate(archiver archive.Archiver, archives []string, m *config.ManifestFile, replacements map[string]interface{}, dir string, format bool) error {
+	postProcessors, err := generate.PostProcessors(archiver, archives, m, replacements, format)
+	if err != nil {
+		return err
+	}
+	return archiver.ExtractLayers(archives, dir, replacements, postProcessors...)
+}
+
+func printChanges(out io.Writer, changes []*merge.Change) {
+	conflicts := 0
+	for _, change := range changes {
+		fmt.Fprintf(out, "%-8s %s\n", change.Action, change.Path)
+		if change.Action == merge.Conflicted {
+			conflicts++
+		}
+	}
+	if conflicts > 0 {
+		fmt.Fprintf(out, "%d file(s) contain conflicting changes which need to be resolved manually\n", conflicts)
+	}
+}
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/template/archive"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/bmuschko/letsgopher/template/storage"
+	"github.com/bmuschko/letsgopher/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUpdateProjectWithoutProvenanceFile(t *testing.T) {
+	projectDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	projectUpdate := &projectUpdateCmd{
+		projectDir: projectDir,
+		toVersion:  "2.0.0",
+		out:        bytes.NewBuffer(nil),
+	}
+	err := projectUpdate.run()
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "failed to load provenance file of project")
+}
+
+func TestUpdateProjectToUninstalledVersion(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	projectDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	writeUpdateTemplates(t, tmpHome, []testhelper.TestFile{{Name: "manifest.yaml", Content: `version: "1.0.0"`}}, nil)
+	writeProvenance(t, tmpHome, projectDir, "1.0.0", map[string]interface{}{})
+	projectUpdate := &projectUpdateCmd{
+		projectDir: projectDir,
+		toVersion:  "3.0.0",
+		out:        bytes.NewBuffer(nil),
+		home:       storage.Home(tmpHome),
+	}
+	err := projectUpdate.run()
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "template with name \"hello-world\" and version \"3.0.0\" hasn't been installed", err.Error())
+}
+
+func TestUpdateProjectMergesTemplateChanges(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	projectDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	writeUpdateTemplates(t, tmpHome, []testhelper.TestFile{
+		{Name: "manifest.yaml", Content: `version: "1.0.0"
+parameters:
+  - name: "name"
+    prompt: "Name"
+    type: "string"`},
+		{Name: "README.md", Content: "# {{ .name }}\n\nGenerated project.\n\n## Usage\n\nRun make.\n"},
+		{Name: "main.go", Content: "package main\n\nfunc main() {\n\tprintln(\"{{ .name }}\")\n}\n"},
+		{Name: "Makefile", Content: "build:\n\tgo build\n"},
+	}, []testhelper.TestFile{
+		{Name: "manifest.yaml", Content: `version: "1.0.0"
+parameters:
+  - name: "name"
+    prompt: "Name"
+    type: "string"
+  - name: "port"
+    prompt: "Port"
+    type: "integer"`},
+		{Name: "README.md", Content: "# {{ .name }}\n\nGenerated project listening on port {{ .port }}.\n\n## Usage\n\nRun make.\n"},
+		{Name: "main.go", Content: "package main\n\nfunc main() {\n\tprintln(\"{{ .name }} on {{ .port }}\")\n}\n"},
+		{Name: "Dockerfile", Content: "EXPOSE {{ .port }}\n"},
+	})
+	testhelper.WriteFile(t, filepath.Join(projectDir, "README.md"), "# hello\n\nGenerated project.\n\n## Usage\n\nRun make build.\n", 0644)
+	testhelper.WriteFile(t, filepath.Join(projectDir, "main.go"), "package main\n\nfunc main() {\n\tprintln(\"hello world\")\n}\n", 0644)
+	testhelper.WriteFile(t, filepath.Join(projectDir, "Makefile"), "build:\n\tgo build\n", 0644)
+	writeProvenance(t, tmpHome, projectDir, "1.0.0", map[string]interface{}{"name": "hello"})
+
+	b := bytes.NewBuffer(nil)
+	pM := new(PrompterMock)
+	projectUpdate := &projectUpdateCmd{
+		projectDir: projectDir,
+		toVersion:  "2.0.0",
+		out:        b,
+		home:       storage.Home(tmpHome),
+		archiver:   newZIPArchiver(),
+		prompter:   pM,
+	}
+	pM.On("Prompt", mock.MatchedBy(func(p *config.Parameter) bool { return p.Name == "port" }), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(map[string]interface{})["port"] = 8080
+	})
+	err := projectUpdate.run()
+
+	pM.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, fmt.Sprintf(`added    Dockerfile
+removed  Makefile
+updated  README.md
+conflict main.go
+1 file(s) contain conflicting changes which need to be resolved manually
+updated project at %q from version "1.0.0" to "2.0.0"
+`, projectDir), b.String())
+	assert.Equal(t, "EXPOSE 8080\n", testhelper.ReadFile(t, filepath.Join(projectDir, "Dockerfile")))
+	assert.Equal(t, "# hello\n\nGenerated project listening on port 8080.\n\n## Usage\n\nRun make build.\n", testhelper.ReadFile(t, filepath.Join(projectDir, "README.md")))
+	assert.Equal(t, `package main
+
+func main() {
+<<<<<<< current
+	println("hello world")
+||||||| 1.0.0
+	println("hello")
+=======
+	println("hello on 8080")
+>>>>>>> 2.0.0
+}
+`, testhelper.ReadFile(t, filepath.Join(projectDir, "main.go")))
+	testhelper.FileNotExists(t, filepath.Join(projectDir, "Makefile"))
+
+	p, err := config.LoadProvenanceFile(filepath.Join(projectDir, config.ProvenanceFileName))
+	assert.Nil(t, err)
+	assert.Equal(t, "2.0.0", p.Template.Version)
+	assert.Equal(t, map[string]string{"name": "hello", "port": "8080"}, p.Answers())
+}
+
+func TestUpdateProjectKeepsRecordedDependencies(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	projectDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	writeUpdateTemplates(t, tmpHome, []testhelper.TestFile{
+		{Name: "manifest.yaml", Content: `version: "1.0.0"`},
+		{Name: "README.md", Content: "# hello\n"},
+	}, []testhelper.TestFile{
+		{Name: "manifest.yaml", Content: `version: "1.0.0"`},
+		{Name: "README.md", Content: "# hello world\n"},
+	})
+	testhelper.WriteFile(t, filepath.Join(projectDir, "README.md"), "# hello\n", 0644)
+	writeProvenance(t, tmpHome, projectDir, "1.0.0", map[string]interface{}{})
+	provenanceFile := filepath.Join(projectDir, config.ProvenanceFileName)
+	p, err := config.LoadProvenanceFile(provenanceFile)
+	assert.Nil(t, err)
+	dependencies := []*config.DependencyReference{{
+		TemplateReference: config.TemplateReference{Name: "ci", Version: "1.3.0", Digest: "sha256:abc"},
+		Directory:         ".github",
+		Parameters:        map[string]interface{}{"project": "hello-ci"},
+	}}
+	p.Dependencies = dependencies
+	err = p.WriteFile(provenanceFile, 0644)
+	assert.Nil(t, err)
+
+	b := bytes.NewBuffer(nil)
+	projectUpdate := &projectUpdateCmd{
+		projectDir: projectDir,
+		toVersion:  "2.0.0",
+		out:        b,
+		home:       storage.Home(tmpHome),
+		archiver:   newZIPArchiver(),
+		prompter:   new(PrompterMock),
+	}
+	err = projectUpdate.run()
+
+	assert.Nil(t, err)
+	assert.Equal(t, fmt.Sprintf(`updated  README.md
+dependency "ci" with version "1.3.0" in ".github" has not been updated
+updated project at %q from version "1.0.0" to "2.0.0"
+`, projectDir), b.String())
+	p, err = config.LoadProvenanceFile(provenanceFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "2.0.0", p.Template.Version)
+	assert.Equal(t, dependencies, p.Dependencies)
+}
+
+func newZIPArchiver() archive.Archiver {
+	return &archive.ZIPArchiver{Processor: &archive.TemplateProcessor{}}
+}
+
+func writeUpdateTemplates(t *testing.T, tmpHome string, from []testhelper.TestFile, to []testhelper.TestFile) {
+	archiveDir := storage.Home(tmpHome).ArchiveDir()
+	err := os.MkdirAll(archiveDir, 0755)
+	if err != nil {
+		t.Fatalf("Failed to create archive directory. Reason: %s", err)
+	}
+	testhelper.CreateZip(t, filepath.Join(archiveDir, "hello-world-1.0.0.zip"), from)
+	if to != nil {
+		testhelper.CreateZip(t, filepath.Join(archiveDir, "hello-world-2.0.0.zip"), to)
+	}
+	testhelper.WriteFile(t, storage.Home(tmpHome).TemplatesFile(), fmt.Sprintf(`generated: "2019-03-15T16:31:57.232715-06:00"
+templates:
+- archivePath: %s
+  name: hello-world
+  version: 1.0.0
+- archivePath: %s
+  name: hello-world
+  version: 2.0.0`, filepath.Join(archiveDir, "hello-world-1.0.0.zip"), filepath.Join(archiveDir, "hello-world-2.0.0.zip")), 0644)
+}
+
+func writeProvenance(t *testing.T, tmpHome string, projectDir string, version string, replacements map[string]interface{}) {
+	archiveZip := filepath.Join(storage.Home(tmpHome).ArchiveDir(), "hello-world-"+version+".zip")
+	digest, err := archive.Digest(archiveZip)
+	if err != nil {
+		t.Fatalf("Failed to calculate digest of %s. Reason: %s", archiveZip, err)
+	}
+	ref := config.TemplateReference{Name: "hello-world", Version: version, Digest: digest}
+	err = config.NewProvenanceFile(ref, []*config.Parameter{}, replacements).WriteFile(filepath.Join(projectDir, config.ProvenanceFileName), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write provenance file. Reason: %s", err)
+	}
+}
+
+type PrompterMock struct {
+	mock.Mock
+}
+
+func (p *PrompterMock) Prompt(param *config.Parameter, replacements map[string]interface{}) error {
+	args := p.Called(param, replacements)
+	return args.Error(0)
+}
@@ -1,7 +1,10 @@
 package archive
 
 // Archiver handles archive files.
+// The post-processors passed to an extraction are only applied to the files of that extraction.
 type Archiver interface {
-	Extract(archiveFile string, targetDir string, replacements map[string]interface{}) error
+	ExtractLayers(archiveFiles []string, targetDir string, replacements map[string]interface{}, postProcessors ...PostProcessor) error
+	CheckConflicts(archiveFiles []string, targetDir string, replacements map[string]interface{}) error
 	LoadManifestFile(src string) ([]byte, error)
+	LoadFile(src string, name string) ([]byte, error)
 }
@@ -0,0 +1,15 @@
+package archive
+
+import "bytes"
+
+// binarySniffLength is the number of leading bytes inspected to tell binary from text files.
+const binarySniffLength = 8000
+
+// IsBinary determines whether the content of a file is binary, i.e. contains a NUL byte within its leading bytes.
+// Binary files can't be rendered as templates or merged line by line.
+func IsBinary(content []byte) bool {
+	if len(content) > binarySniffLength {
+		content = content[:binarySniffLength]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
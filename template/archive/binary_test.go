@@ -0,0 +1,14 @@
+package archive
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIsBinary(t *testing.T) {
+	assert.False(t, IsBinary([]byte("package main\n")))
+	assert.False(t, IsBinary([]byte{}))
+	assert.True(t, IsBinary([]byte{0x89, 'P', 'N', 'G', 0x00}))
+	assert.False(t, IsBinary(append(bytes.Repeat([]byte("a"), binarySniffLength), 0x00)))
+}
@@ -0,0 +1,43 @@
+package archive
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConflictPolicy determines how files already existing in the target directory are treated on extraction.
+type ConflictPolicy string
+
+const (
+	// OverwriteConflicts replaces existing files with the extracted files.
+	OverwriteConflicts ConflictPolicy = "overwrite"
+
+	// SkipConflicts keeps existing files and only extracts files not existing yet.
+	SkipConflicts ConflictPolicy = "skip"
+
+	// FailOnConflicts aborts the extraction before writing any file if at least one of the files already exists.
+	FailOnConflicts ConflictPolicy = "fail"
+)
+
+var conflictPolicies = []ConflictPolicy{FailOnConflicts, SkipConflicts, OverwriteConflicts}
+
+// ParseConflictPolicy converts the name of a conflict policy into a ConflictPolicy.
+func ParseConflictPolicy(s string) (ConflictPolicy, error) {
+	names := []string{}
+	for _, p := range conflictPolicies {
+		if string(p) == s {
+			return p, nil
+		}
+		names = append(names, string(p))
+	}
+	return "", fmt.Errorf("unknown conflict policy %q, expected one of [%s]", s, strings.Join(names, ", "))
+}
+
+// ConflictError reports files which already exist in the target directory.
+type ConflictError struct {
+	Paths []string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("the following files already exist:\n  %s", strings.Join(e.Paths, "\n  "))
+}
@@ -0,0 +1,49 @@
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	digestAlgorithm = "sha256"
+
+	checksumFileExtension = ".sha256"
+)
+
+// Digest calculates the SHA-256 digest of an archive file in the form "sha256:<hex>".
+func Digest(archiveFile string) (string, error) {
+	f, err := os.Open(archiveFile)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+	return digestAlgorithm + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// WriteChecksumFile writes the SHA-256 digest of an archive file to a file next to it, e.g. hello-world-1.0.0.zip.sha256.
+// The content follows the format of the sha256sum tool and can be verified with "sha256sum -c". Returns the path of the checksum file.
+func WriteChecksumFile(archiveFile string) (string, error) {
+	digest, err := Digest(archiveFile)
+	if err != nil {
+		return "", err
+	}
+	checksumFile := archiveFile + checksumFileExtension
+	content := strings.TrimPrefix(digest, digestAlgorithm+":") + "  " + filepath.Base(archiveFile) + "\n"
+	err = ioutil.WriteFile(checksumFile, []byte(content), 0644)
+	if err != nil {
+		return "", err
+	}
+	return checksumFile, nil
+}
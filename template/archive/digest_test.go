@@ -0,0 +1,41 @@
+package archive
+
+import (
+	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/testhelper"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func TestDigest(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	f := filepath.Join(tmpDir, "hello-world-1.0.0.zip")
+	testhelper.WriteFile(t, f, "hello", 0644)
+	digest, err := Digest(f)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", digest)
+}
+
+func TestDigestForNonExistentFile(t *testing.T) {
+	digest, err := Digest("/does/not/exist.zip")
+
+	assert.Equal(t, "", digest)
+	assert.NotNil(t, err)
+}
+
+func TestWriteChecksumFile(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	f := filepath.Join(tmpDir, "hello-world-1.0.0.zip")
+	testhelper.WriteFile(t, f, "hello", 0644)
+	checksumFile, err := WriteChecksumFile(f)
+
+	assert.Nil(t, err)
+	assert.Equal(t, f+".sha256", checksumFile)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824  hello-world-1.0.0.zip\n", testhelper.ReadFile(t, checksumFile))
+}
@@ -0,0 +1,18 @@
+package archive
+
+import (
+	"go/format"
+	"path/filepath"
+)
+
+// GoFormatter formats generated Go source files the same way as gofmt.
+type GoFormatter struct {
+}
+
+// PostProcess formats the content of files with the extension .go and leaves all other files untouched.
+func (f *GoFormatter) PostProcess(name string, content []byte) ([]byte, error) {
+	if filepath.Ext(name) != ".go" {
+		return content, nil
+	}
+	return format.Source(content)
+}
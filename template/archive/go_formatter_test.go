@@ -0,0 +1,29 @@
+package archive
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPostProcessFormatsGoFiles(t *testing.T) {
+	formatter := &GoFormatter{}
+	content, err := formatter.PostProcess("main.go", []byte("package main\nfunc main() {\n  println( \"hello\" )\n}\n"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "package main\n\nfunc main() {\n\tprintln(\"hello\")\n}\n", string(content))
+}
+
+func TestPostProcessIgnoresOtherFiles(t *testing.T) {
+	formatter := &GoFormatter{}
+	content, err := formatter.PostProcess("README.md", []byte("func  main( )"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "func  main( )", string(content))
+}
+
+func TestPostProcessRejectsInvalidGoFiles(t *testing.T) {
+	formatter := &GoFormatter{}
+	_, err := formatter.PostProcess("main.go", []byte("package main\nfunc main() {"))
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,98 @@
+package archive
+
+import (
+	"path"
+	"strings"
+)
+
+// IgnoreFileName is the name of the file listing the files of a template directory excluded from its archive.
+const IgnoreFileName = ".letsgopherignore"
+
+// IgnoreList decides which files of a template directory are excluded from its archive.
+// Patterns follow the rules of .gitignore files: a pattern without a slash matches the name of a file or directory
+// at any depth, other patterns match paths relative to the template directory, a trailing slash only matches directories,
+// ** matches any number of directories and a leading ! includes a previously excluded file again.
+type IgnoreList struct {
+	rules []*ignoreRule
+}
+
+type ignoreRule struct {
+	segments []string
+	anchored bool
+	dirOnly  bool
+	negate   bool
+}
+
+// ParseIgnoreList parses the content of an ignore file. Empty lines and lines starting with # are skipped.
+func ParseIgnoreList(content []byte) *IgnoreList {
+	l := &IgnoreList{}
+	for _, line := range strings.Split(string(content), "\n") {
+		l.Add(line)
+	}
+	return l
+}
+
+// Add appends a pattern to the list. Later patterns take precedence over earlier patterns.
+func (l *IgnoreList) Add(pattern string) {
+	pattern = strings.TrimRight(pattern, " \t\r")
+	if pattern == "" || strings.HasPrefix(pattern, "#") {
+		return
+	}
+	r := &ignoreRule{}
+	if strings.HasPrefix(pattern, "!") {
+		r.negate = true
+		pattern = pattern[1:]
+	}
+	if strings.HasSuffix(pattern, "/") {
+		r.dirOnly = true
+		pattern = strings.TrimRight(pattern, "/")
+	}
+	r.anchored = strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if pattern == "" {
+		return
+	}
+	r.segments = strings.Split(pattern, "/")
+	l.rules = append(l.rules, r)
+}
+
+// Ignored determines whether a file or directory is excluded. The name is the slash-separated path relative to the template directory.
+func (l *IgnoreList) Ignored(name string, dir bool) bool {
+	ignored := false
+	for _, r := range l.rules {
+		if r.dirOnly && !dir {
+			continue
+		}
+		if r.matches(name) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+func (r *ignoreRule) matches(name string) bool {
+	if !r.anchored {
+		ok, _ := path.Match(r.segments[0], path.Base(name))
+		return ok
+	}
+	return matchSegments(r.segments, strings.Split(name, "/"))
+}
+
+func matchSegments(pattern []string, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(name) == 0 {
+		return false
+	}
+	ok, _ := path.Match(pattern[0], name[0])
+	return ok && matchSegments(pattern[1:], name[1:])
+}
@@ -0,0 +1,40 @@
+package archive
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIgnoreList(t *testing.T) {
+	l := ParseIgnoreList([]byte(`# build output
+*.log
+!keep.log
+/tests/
+docs/**/*.png
+vendor/
+`))
+	files := []struct {
+		name     string
+		dir      bool
+		expected bool
+	}{
+		{"main.go", false, false},
+		{"debug.log", false, true},
+		{"cmd/debug.log", false, true},
+		{"keep.log", false, false},
+		{"tests", true, true},
+		{"cmd/tests", true, false},
+		{"docs/logo.png", false, true},
+		{"docs/images/logo.png", false, true},
+		{"logo.png", false, false},
+		{"vendor", true, true},
+		{"internal/vendor", true, true},
+		{"vendor", false, false},
+	}
+
+	for _, f := range files {
+		t.Run(f.name, func(t *testing.T) {
+			assert.Equal(t, f.expected, l.Ignored(f.name, f.dir))
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package archive
+
+import (
+	"github.com/bmuschko/letsgopher/template/gomod"
+	"path/filepath"
+)
+
+// ModuleRewriter replaces the placeholder module path of a template with the module path of the generated project.
+// It rewrites the module directive of go.mod files and the import paths of Go source files.
+type ModuleRewriter struct {
+	From string
+	To   string
+}
+
+// PostProcess rewrites go.mod files and Go source files referring to the placeholder module and leaves all other files untouched.
+func (m *ModuleRewriter) PostProcess(name string, content []byte) ([]byte, error) {
+	if m.From == m.To {
+		return content, nil
+	}
+	if filepath.Base(name) == gomod.FileName {
+		f, err := gomod.Parse(content)
+		if err != nil {
+			return nil, err
+		}
+		if !gomod.Matches(f.Module, m.From) {
+			return content, nil
+		}
+		return gomod.SetModulePath(content, m.To+f.Module[len(m.From):])
+	}
+	if filepath.Ext(name) == ".go" {
+		return gomod.RewriteImports(name, content, m.From, m.To)
+	}
+	return content, nil
+}
@@ -0,0 +1,253 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PackageFormat is the file format of a packaged template archive.
+type PackageFormat string
+
+const (
+	// ZIPFormat packages a template as ZIP file which can be installed with letsgopher.
+	ZIPFormat PackageFormat = "zip"
+
+	// TarGzFormat packages a template as gzip-compressed tarball.
+	TarGzFormat PackageFormat = "tar.gz"
+)
+
+var packageFormats = []PackageFormat{ZIPFormat, TarGzFormat}
+
+// packageTime is the modification time of all archive entries to make archives reproducible.
+var packageTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// defaultIgnorePatterns lists the files never included in a template archive.
+var defaultIgnorePatterns = []string{".git/", IgnoreFileName}
+
+// ParsePackageFormat converts the name of a package format into a PackageFormat.
+func ParsePackageFormat(s string) (PackageFormat, error) {
+	names := []string{}
+	for _, f := range packageFormats {
+		if string(f) == s {
+			return f, nil
+		}
+		names = append(names, string(f))
+	}
+	return "", fmt.Errorf("unknown package format %q, expected one of [%s]", s, strings.Join(names, ", "))
+}
+
+// packageEntry is a file or directory of a template directory.
+type packageEntry struct {
+	name string
+	path string
+	dir  bool
+	mode os.FileMode
+}
+
+// Package bundles the files of a template directory into an archive file.
+// Files excluded by the .letsgopherignore file of the directory and Git metadata are skipped.
+// Archives and checksum files in the directory of the archive file are skipped as well, so that packaging into the template directory doesn't bundle earlier packages.
+// The archive is reproducible: entries are sorted by name and carry a fixed modification time and normalized permissions.
+func Package(dir string, archiveFile string, format PackageFormat) error {
+	return PackageExcluding(dir, archiveFile, format)
+}
+
+// PackageExcluding bundles the files of a template directory into an archive file like Package.
+// Files matching any of the additional ignore patterns are skipped as well.
+func PackageExcluding(dir string, archiveFile string, format PackageFormat, patterns ...string) error {
+	entries, err := packageEntries(dir, archiveFile, patterns)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(archiveFile), 0755)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(archiveFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if format == TarGzFormat {
+		err = writeTarGz(f, entries)
+	} else {
+		err = writeZIP(f, entries)
+	}
+	if err == nil {
+		err = f.Close()
+	}
+	if err != nil {
+		os.Remove(archiveFile)
+	}
+	return err
+}
+
+func packageEntries(dir string, archiveFile string, patterns []string) ([]*packageEntry, error) {
+	ignores, err := loadIgnoreList(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range patterns {
+		ignores.Add(p)
+	}
+	absArchiveFile, err := filepath.Abs(archiveFile)
+	if err != nil {
+		return nil, err
+	}
+	destination := filepath.Dir(absArchiveFile)
+
+	entries := []*packageEntry{}
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		name := filepath.ToSlash(rel)
+		if ignores.Ignored(name, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("template file %q is a symbolic link which can't be packaged", name)
+		}
+		if info.IsDir() {
+			entries = append(entries, &packageEntry{name: name + "/", path: path, dir: true, mode: 0755})
+			return nil
+		}
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		if filepath.Dir(absPath) == destination && isPackageOutput(name) {
+			return nil
+		}
+		mode := os.FileMode(0644)
+		if info.Mode()&0111 != 0 {
+			mode = 0755
+		}
+		entries = append(entries, &packageEntry{name: name, path: path, mode: mode})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].name < entries[j].name
+	})
+	return entries, nil
+}
+
+// isPackageOutput determines whether a file is an archive or a checksum file written by packaging a template.
+func isPackageOutput(name string) bool {
+	if strings.HasSuffix(name, checksumFileExtension) {
+		return true
+	}
+	for _, f := range packageFormats {
+		if strings.HasSuffix(name, "."+string(f)) {
+			return true
+		}
+	}
+	return false
+}
+
+func loadIgnoreList(dir string) (*IgnoreList, error) {
+	ignores := &IgnoreList{}
+	for _, p := range defaultIgnorePatterns {
+		ignores.Add(p)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dir, IgnoreFileName))
+	if os.IsNotExist(err) {
+		return ignores, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	ignores.rules = append(ignores.rules, ParseIgnoreList(b).rules...)
+	return ignores, nil
+}
+
+func writeZIP(out io.Writer, entries []*packageEntry) error {
+	w := zip.NewWriter(out)
+	for _, e := range entries {
+		h := &zip.FileHeader{Name: e.name, Method: zip.Deflate, Modified: packageTime}
+		if e.dir {
+			h.Method = zip.Store
+			h.SetMode(os.ModeDir | e.mode)
+		} else {
+			h.SetMode(e.mode)
+		}
+		fw, err := w.CreateHeader(h)
+		if err != nil {
+			return err
+		}
+		if e.dir {
+			continue
+		}
+		err = copyFile(fw, e.path)
+		if err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+func writeTarGz(out io.Writer, entries []*packageEntry) error {
+	gw := gzip.NewWriter(out)
+	w := tar.NewWriter(gw)
+	for _, e := range entries {
+		h := &tar.Header{Name: e.name, Mode: int64(e.mode), ModTime: packageTime, Typeflag: tar.TypeReg}
+		if e.dir {
+			h.Typeflag = tar.TypeDir
+		} else {
+			info, err := os.Stat(e.path)
+			if err != nil {
+				return err
+			}
+			h.Size = info.Size()
+		}
+		err := w.WriteHeader(h)
+		if err != nil {
+			return err
+		}
+		if e.dir {
+			continue
+		}
+		err = copyFile(w, e.path)
+		if err != nil {
+			return err
+		}
+	}
+	err := w.Close()
+	if err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func copyFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
@@ -0,0 +1,158 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/testhelper"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPackageZIP(t *testing.T) {
+	dir := writePackageTemplate(t)
+	defer filet.CleanUp(t)
+
+	archiveFile := filepath.Join(dir, "hello-world-1.0.0.zip")
+	err := Package(dir, archiveFile, ZIPFormat)
+	assert.Nil(t, err)
+
+	r, err := zip.OpenReader(archiveFile)
+	assert.Nil(t, err)
+	defer r.Close()
+	names := []string{}
+	for _, f := range r.File {
+		names = append(names, f.Name)
+		assert.True(t, packageTime.Equal(f.Modified.UTC()))
+	}
+	assert.Equal(t, []string{"cmd/", "cmd/main.go", "manifest.yaml", "run.sh"}, names)
+	assert.Equal(t, os.ModeDir|os.FileMode(0755), r.File[0].Mode())
+	assert.Equal(t, os.FileMode(0644), r.File[1].Mode())
+	assert.Equal(t, os.FileMode(0755), r.File[3].Mode())
+}
+
+func TestPackageExcluding(t *testing.T) {
+	dir := writePackageTemplate(t)
+	defer filet.CleanUp(t)
+
+	archiveFile := filepath.Join(dir, "hello-world-1.0.0.zip")
+	err := PackageExcluding(dir, archiveFile, ZIPFormat, "/cmd/", "*.sh")
+	assert.Nil(t, err)
+
+	r, err := zip.OpenReader(archiveFile)
+	assert.Nil(t, err)
+	defer r.Close()
+	names := []string{}
+	for _, f := range r.File {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"manifest.yaml"}, names)
+}
+
+func TestPackageSkipsEarlierPackagesInDestination(t *testing.T) {
+	dir := writePackageTemplate(t)
+	defer filet.CleanUp(t)
+
+	testhelper.WriteFile(t, filepath.Join(dir, "hello-world-0.9.0.zip"), "zip", 0644)
+	testhelper.WriteFile(t, filepath.Join(dir, "hello-world-0.9.0.zip.sha256"), "checksum", 0644)
+	testhelper.WriteFile(t, filepath.Join(dir, "hello-world-0.8.0.tar.gz"), "tarball", 0644)
+	testhelper.WriteFile(t, filepath.Join(dir, "cmd", "testdata.zip"), "zip", 0644)
+	archiveFile := filepath.Join(dir, "hello-world-1.0.0.zip")
+	err := Package(dir, archiveFile, ZIPFormat)
+	assert.Nil(t, err)
+
+	r, err := zip.OpenReader(archiveFile)
+	assert.Nil(t, err)
+	defer r.Close()
+	names := []string{}
+	for _, f := range r.File {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"cmd/", "cmd/main.go", "cmd/testdata.zip", "manifest.yaml", "run.sh"}, names)
+}
+
+func TestPackageIsReproducible(t *testing.T) {
+	dir := writePackageTemplate(t)
+	defer filet.CleanUp(t)
+	out := filet.TmpDir(t, "")
+
+	first := filepath.Join(out, "first.zip")
+	err := Package(dir, first, ZIPFormat)
+	assert.Nil(t, err)
+	now := time.Now().Add(time.Hour)
+	assert.Nil(t, os.Chtimes(filepath.Join(dir, "manifest.yaml"), now, now))
+	second := filepath.Join(out, "second.zip")
+	err = Package(dir, second, ZIPFormat)
+	assert.Nil(t, err)
+
+	firstDigest, err := Digest(first)
+	assert.Nil(t, err)
+	secondDigest, err := Digest(second)
+	assert.Nil(t, err)
+	assert.Equal(t, firstDigest, secondDigest)
+}
+
+func TestPackageTarGz(t *testing.T) {
+	dir := writePackageTemplate(t)
+	defer filet.CleanUp(t)
+
+	archiveFile := filepath.Join(filet.TmpDir(t, ""), "hello-world-1.0.0.tar.gz")
+	err := Package(dir, archiveFile, TarGzFormat)
+	assert.Nil(t, err)
+
+	f, err := os.Open(archiveFile)
+	assert.Nil(t, err)
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	assert.Nil(t, err)
+	r := tar.NewReader(gr)
+	names := []string{}
+	for {
+		h, err := r.Next()
+		if err != nil {
+			break
+		}
+		names = append(names, h.Name)
+		assert.True(t, packageTime.Equal(h.ModTime))
+		if h.Name == "cmd/main.go" {
+			b, err := ioutil.ReadAll(r)
+			assert.Nil(t, err)
+			assert.Equal(t, "package {{ .name }}", string(b))
+		}
+	}
+	assert.Equal(t, []string{"cmd/", "cmd/main.go", "manifest.yaml", "run.sh"}, names)
+}
+
+func TestParsePackageFormat(t *testing.T) {
+	format, err := ParsePackageFormat("tar.gz")
+	assert.Nil(t, err)
+	assert.Equal(t, TarGzFormat, format)
+
+	_, err = ParsePackageFormat("rar")
+	assert.NotNil(t, err)
+	assert.Equal(t, "unknown package format \"rar\", expected one of [zip, tar.gz]", err.Error())
+}
+
+// writePackageTemplate creates a template directory with files excluded by default and by the ignore file.
+func writePackageTemplate(t *testing.T) string {
+	dir := filet.TmpDir(t, "")
+	for _, d := range []string{"cmd", ".git", "tests/default"} {
+		err := os.MkdirAll(filepath.Join(dir, d), 0755)
+		if err != nil {
+			t.Fatalf("Failed to create directory %s. Reason: %s", d, err)
+		}
+	}
+	testhelper.WriteFile(t, filepath.Join(dir, "manifest.yaml"), "version: \"1.0.0\"", 0644)
+	testhelper.WriteFile(t, filepath.Join(dir, "cmd", "main.go"), "package {{ .name }}", 0600)
+	testhelper.WriteFile(t, filepath.Join(dir, "run.sh"), "#!/bin/sh", 0700)
+	testhelper.WriteFile(t, filepath.Join(dir, "debug.log"), "debug", 0644)
+	testhelper.WriteFile(t, filepath.Join(dir, ".git", "HEAD"), "ref: refs/heads/master", 0644)
+	testhelper.WriteFile(t, filepath.Join(dir, "tests", "default", "answers.yaml"), "name: hello", 0644)
+	testhelper.WriteFile(t, filepath.Join(dir, IgnoreFileName), "*.log\n/tests/\n", 0644)
+	return dir
+}
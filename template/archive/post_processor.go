@@ -0,0 +1,6 @@
+package archive
+
+// PostProcessor transforms the content of a generated file before it is written.
+type PostProcessor interface {
+	PostProcess(name string, content []byte) ([]byte, error)
+}
@@ -3,6 +3,8 @@ package archive
 import "io"
 
 // Processor replaces placeholders in text content with values.
+// Partials map the names of templates which can be invoked from the content to their content.
 type Processor interface {
-	Process(content []byte, target io.Writer, replacements map[string]interface{}) error
+	Process(content []byte, target io.Writer, replacements map[string]interface{}, partials map[string]string) error
+	CheckPartial(name string, content []byte) error
 }
@@ -1,23 +1,51 @@
 package archive
 
 import (
+	"fmt"
+	"github.com/bmuschko/letsgopher/template/expression"
 	"io"
+	"sort"
 	"text/template"
 )
 
 // TemplateProcessor replaces placeholders in text content with values using Go's templating functionality.
-type TemplateProcessor struct {
+type TemplateProcessor struct{}
+
+// CheckPartial verifies that a partial can be parsed as template.
+func (tp *TemplateProcessor) CheckPartial(name string, content []byte) error {
+	_, err := template.New(name).Funcs(expression.FuncMap()).Parse(string(content))
+	if err != nil {
+		return fmt.Errorf("failed to parse partial %q: %s", name, err)
+	}
+	return nil
 }
 
-// Process performs placeholder replacement.
-func (tp *TemplateProcessor) Process(content []byte, target io.Writer, replacements map[string]interface{}) error {
-	template, err := template.New("").Parse(string(content))
+// Process performs placeholder replacement. The partials can be invoked from the content with the template action.
+// Errors of parsing the content and of executing the template, e.g. invoking a partial which doesn't exist, are returned.
+func (tp *TemplateProcessor) Process(content []byte, target io.Writer, replacements map[string]interface{}, partials map[string]string) error {
+	template := template.New("").Funcs(expression.FuncMap())
+	for _, name := range partialNames(partials) {
+		_, err := template.New(name).Parse(partials[name])
+		if err != nil {
+			return fmt.Errorf("failed to parse partial %q: %s", name, err)
+		}
+	}
+	template, err := template.Parse(string(content))
 	if err != nil {
-		return nil
+		return fmt.Errorf("failed to parse template: %s", err)
 	}
 	err = template.ExecuteTemplate(target, "", replacements)
 	if err != nil {
-		return nil
+		return fmt.Errorf("failed to render template: %s", err)
 	}
 	return nil
 }
+
+func partialNames(partials map[string]string) []string {
+	names := []string{}
+	for name := range partials {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
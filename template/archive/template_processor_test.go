@@ -12,7 +12,7 @@ func TestProcessTemplateWithoutReplacements(t *testing.T) {
 	processor := TemplateProcessor{}
 	replacements := make(map[string]interface{})
 	replacements["var"] = "world"
-	err := processor.Process(content, buf, replacements)
+	err := processor.Process(content, buf, replacements, nil)
 
 	assert.Nil(t, err)
 	assert.Equal(t, "hello", buf.String())
@@ -24,7 +24,7 @@ func TestProcessTemplateWithSingleReplacement(t *testing.T) {
 	processor := TemplateProcessor{}
 	replacements := make(map[string]interface{})
 	replacements["var"] = "world"
-	err := processor.Process(content, buf, replacements)
+	err := processor.Process(content, buf, replacements, nil)
 
 	assert.Nil(t, err)
 	assert.Equal(t, "hello world", buf.String())
@@ -39,7 +39,7 @@ this is a test
 	replacements := make(map[string]interface{})
 	replacements["var"] = "world"
 	replacements["message"] = "bye"
-	err := processor.Process(content, buf, replacements)
+	err := processor.Process(content, buf, replacements, nil)
 
 	assert.Nil(t, err)
 	assert.Equal(t, `hello world
@@ -68,7 +68,7 @@ Show this section if the condition is false
 			processor := TemplateProcessor{}
 			replacements := make(map[string]interface{})
 			replacements["condition"] = r.value
-			err := processor.Process(content, buf, replacements)
+			err := processor.Process(content, buf, replacements, nil)
 
 			assert.Nil(t, err)
 			assert.Equal(t, r.expectedOutput, buf.String())
@@ -80,3 +80,73 @@ type replacement struct {
 	value          string
 	expectedOutput string
 }
+
+func TestProcessTemplateWithFunctions(t *testing.T) {
+	content := []byte(`package {{ packageName .name }}`)
+	buf := bytes.NewBufferString("")
+	processor := TemplateProcessor{}
+	replacements := make(map[string]interface{})
+	replacements["name"] = "order-service"
+	err := processor.Process(content, buf, replacements, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "package orderservice", buf.String())
+}
+
+func TestProcessTemplateWithPartial(t *testing.T) {
+	content := []byte(`{{ template "license-header" . }}
+package main`)
+	buf := bytes.NewBufferString("")
+	processor := TemplateProcessor{}
+	replacements := make(map[string]interface{})
+	replacements["author"] = "John Doe"
+	err := processor.Process(content, buf, replacements, map[string]string{"license-header": `// Copyright {{ .author }}`})
+
+	assert.Nil(t, err)
+	assert.Equal(t, `// Copyright John Doe
+package main`, buf.String())
+}
+
+func TestCheckInvalidPartial(t *testing.T) {
+	processor := TemplateProcessor{}
+	err := processor.CheckPartial("license-header", []byte(`// Copyright {{ .author`))
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "failed to parse partial \"license-header\"")
+}
+
+func TestProcessTemplateWithMissingPartial(t *testing.T) {
+	content := []byte(`{{ template "license-header" . }}
+package main`)
+	buf := bytes.NewBufferString("")
+	processor := TemplateProcessor{}
+	err := processor.Process(content, buf, make(map[string]interface{}), nil)
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "failed to render template")
+	assert.Contains(t, err.Error(), "template \"license-header\" not defined")
+}
+
+func TestProcessTemplateWithBrokenPartial(t *testing.T) {
+	content := []byte(`{{ template "license-header" . }}
+package main`)
+	buf := bytes.NewBufferString("")
+	processor := TemplateProcessor{}
+	replacements := make(map[string]interface{})
+	replacements["author"] = "John Doe"
+	err := processor.Process(content, buf, replacements, map[string]string{"license-header": `// Copyright {{ .author.name }}`})
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "failed to render template")
+	assert.Contains(t, err.Error(), "can't evaluate field name")
+}
+
+func TestProcessInvalidTemplate(t *testing.T) {
+	content := []byte(`package {{ .name`)
+	buf := bytes.NewBufferString("")
+	processor := TemplateProcessor{}
+	err := processor.Process(content, buf, make(map[string]interface{}), nil)
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "failed to parse template")
+}
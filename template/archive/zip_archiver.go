@@ -4,47 +4,180 @@ import (
 	"archive/zip"
 	"bytes"
 	"fmt"
+	"github.com/bmuschko/letsgopher/template/config"
 	"io"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 )
 
-const manifestFile = "manifest.yaml"
+const (
+	// partialsDir is the directory of an archive containing partials. Its files are not extracted.
+	partialsDir = "_partials"
+)
 
 // ZIPArchiver handles ZIP archive files.
+// Existing files in the target directory are overwritten unless a different conflict policy is set.
 type ZIPArchiver struct {
 	Processor Processor
+	Conflicts ConflictPolicy
+}
+
+// Extract expands the contents of a ZIP file. The post-processors are applied to every extracted file.
+func (a *ZIPArchiver) Extract(archiveFile string, targetDir string, replacements map[string]interface{}, postProcessors ...PostProcessor) error {
+	return a.ExtractLayers([]string{archiveFile}, targetDir, replacements, postProcessors...)
 }
 
-// Extract expands the contents of a ZIP file.
-func (a *ZIPArchiver) Extract(archiveFile string, targetDir string, replacements map[string]interface{}) error {
-	r, err := zip.OpenReader(archiveFile)
+// ExtractLayers expands the contents of multiple ZIP files into the same directory.
+// A file of a later archive replaces the file with the same path of an earlier archive.
+// The post-processors are applied to every extracted file.
+func (a *ZIPArchiver) ExtractLayers(archiveFiles []string, targetDir string, replacements map[string]interface{}, postProcessors ...PostProcessor) error {
+	entries, closeLayers, err := openLayers(archiveFiles)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if err := r.Close(); err != nil {
-			panic(err)
+	defer closeLayers()
+
+	x := &extraction{archiver: a, targetDir: targetDir, replacements: replacements, partials: make(map[string]string), postProcessors: postProcessors}
+	entries, err = x.addPartials(entries)
+	if err != nil {
+		return err
+	}
+
+	if a.Conflicts == FailOnConflicts {
+		err := x.checkConflicts(entries)
+		if err != nil {
+			return err
 		}
-	}()
+	}
 
 	err = os.MkdirAll(targetDir, 0755)
 	if err != nil {
 		return err
 	}
 
-	for _, f := range r.File {
-		err := a.extractAndWriteFile(f, targetDir, replacements)
+	postProcessErrs := []string{}
+	for _, e := range entries {
+		err := x.extractAndWriteFile(e)
+		if ppErr, ok := err.(*postProcessError); ok {
+			postProcessErrs = append(postProcessErrs, fmt.Sprintf("%s (template source %s:%s): %s", ppErr.path, e.archiveFile, e.file.Name, ppErr.err))
+			continue
+		}
 		if err != nil {
 			return err
 		}
 	}
 
+	if len(postProcessErrs) > 0 {
+		return fmt.Errorf("failed to post-process generated files:\n  %s", strings.Join(postProcessErrs, "\n  "))
+	}
 	return nil
 }
 
-func (a *ZIPArchiver) extractAndWriteFile(f *zip.File, targetDir string, replacements map[string]interface{}) error {
+// CheckConflicts verifies that extracting the ZIP files wouldn't write any file already existing in the target directory.
+// Conflicts are only reported if the conflict policy fails on conflicts.
+func (a *ZIPArchiver) CheckConflicts(archiveFiles []string, targetDir string, replacements map[string]interface{}) error {
+	if a.Conflicts != FailOnConflicts {
+		return nil
+	}
+	entries, closeLayers, err := openLayers(archiveFiles)
+	if err != nil {
+		return err
+	}
+	defer closeLayers()
+
+	x := &extraction{archiver: a, targetDir: targetDir, replacements: replacements, partials: make(map[string]string)}
+	entries, err = x.addPartials(entries)
+	if err != nil {
+		return err
+	}
+	return x.checkConflicts(entries)
+}
+
+// openLayers reads the entries of multiple ZIP files. An entry of a later archive replaces the entry with the same path of an earlier archive.
+// The returned function closes the archives.
+func openLayers(archiveFiles []string) ([]*layerEntry, func(), error) {
+	entries := []*layerEntry{}
+	index := make(map[string]int)
+	readers := []*zip.ReadCloser{}
+	closeLayers := func() {
+		for _, r := range readers {
+			if err := r.Close(); err != nil {
+				panic(err)
+			}
+		}
+	}
+	for _, archiveFile := range archiveFiles {
+		r, err := zip.OpenReader(archiveFile)
+		if err != nil {
+			closeLayers()
+			return nil, nil, err
+		}
+		readers = append(readers, r)
+
+		for _, f := range r.File {
+			e := &layerEntry{archiveFile: archiveFile, file: f}
+			name := path.Clean(f.Name)
+			if i, exists := index[name]; exists {
+				entries[i] = e
+				continue
+			}
+			index[name] = len(entries)
+			entries = append(entries, e)
+		}
+	}
+	return entries, closeLayers, nil
+}
+
+// layerEntry is a file of one of the layered archives.
+type layerEntry struct {
+	archiveFile string
+	file        *zip.File
+}
+
+// extraction holds the state of expanding layered archives into a target directory.
+// The partials and post-processors only apply to a single extraction.
+type extraction struct {
+	archiver       *ZIPArchiver
+	targetDir      string
+	replacements   map[string]interface{}
+	partials       map[string]string
+	postProcessors []PostProcessor
+}
+
+// addPartials collects the files of the partials directory and returns the remaining entries.
+// A partial is named after its path relative to the partials directory without the file extension,
+// e.g. the file _partials/license-header.tmpl can be invoked with {{template "license-header" .}}.
+func (x *extraction) addPartials(entries []*layerEntry) ([]*layerEntry, error) {
+	files := []*layerEntry{}
+	for _, e := range entries {
+		name := path.Clean(e.file.Name)
+		if name != partialsDir && !strings.HasPrefix(name, partialsDir+"/") {
+			files = append(files, e)
+			continue
+		}
+		if e.file.FileInfo().IsDir() {
+			continue
+		}
+		b, err := readZIPFile(e.file)
+		if err != nil {
+			return nil, err
+		}
+		name = strings.TrimPrefix(name, partialsDir+"/")
+		name = strings.TrimSuffix(name, path.Ext(name))
+		err = x.archiver.Processor.CheckPartial(name, b)
+		if err != nil {
+			return nil, fmt.Errorf("%s (template source %s:%s)", err, e.archiveFile, e.file.Name)
+		}
+		x.partials[name] = string(b)
+	}
+	return files, nil
+}
+
+func (x *extraction) extractAndWriteFile(e *layerEntry) error {
+	f := e.file
 	rc, err := f.Open()
 	if err != nil {
 		return err
@@ -55,7 +188,11 @@ func (a *ZIPArchiver) extractAndWriteFile(f *zip.File, targetDir string, replace
 		}
 	}()
 
-	path := filepath.Join(targetDir, f.Name)
+	name, err := x.renderName(f.Name)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(x.targetDir, name)
 
 	if f.FileInfo().IsDir() {
 		err := os.MkdirAll(path, f.Mode())
@@ -64,37 +201,120 @@ func (a *ZIPArchiver) extractAndWriteFile(f *zip.File, targetDir string, replace
 		}
 	} else {
 		// ignore manifest file
-		if filepath.Base(path) == manifestFile {
+		if filepath.Base(path) == config.ManifestFileName {
+			return nil
+		}
+		if x.archiver.Conflicts == SkipConflicts && fileExists(path) {
 			return nil
 		}
-		err := os.MkdirAll(filepath.Dir(path), f.Mode())
+		b, err := ioutil.ReadAll(rc)
 		if err != nil {
 			return err
 		}
-		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.Mode())
+		buf := bytes.NewBuffer(nil)
+		err = x.archiver.Processor.Process(b, buf, x.replacements, x.partials)
 		if err != nil {
-			return err
+			return fmt.Errorf("%s (template source %s:%s)", err, e.archiveFile, f.Name)
 		}
-		defer func() {
-			if err := f.Close(); err != nil {
-				panic(err)
-			}
-		}()
+		content, ppErr := x.postProcess(path, buf.Bytes())
 
-		b, err := ioutil.ReadAll(rc)
+		err = os.MkdirAll(filepath.Dir(path), f.Mode())
 		if err != nil {
-			return nil
+			return err
 		}
-		err = a.Processor.Process(b, f, replacements)
+		err = ioutil.WriteFile(path, content, f.Mode())
 		if err != nil {
-			return nil
+			return err
+		}
+		if ppErr != nil {
+			return &postProcessError{path: path, err: ppErr}
+		}
+	}
+	return nil
+}
+
+func (x *extraction) checkConflicts(entries []*layerEntry) error {
+	conflicts := []string{}
+	for _, e := range entries {
+		f := e.file
+		if f.FileInfo().IsDir() || filepath.Base(f.Name) == config.ManifestFileName {
+			continue
+		}
+		name, err := x.renderName(f.Name)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(x.targetDir, name)
+		if fileExists(path) {
+			conflicts = append(conflicts, path)
 		}
 	}
+	if len(conflicts) > 0 {
+		return &ConflictError{Paths: conflicts}
+	}
 	return nil
 }
 
+// renderName replaces placeholders in the path of an archive entry, e.g. cmd/{{ .appName }}/main.go.
+func (x *extraction) renderName(name string) (string, error) {
+	if !strings.Contains(name, "{{") {
+		return name, nil
+	}
+	buf := bytes.NewBuffer(nil)
+	err := x.archiver.Processor.Process([]byte(name), buf, x.replacements, x.partials)
+	if err != nil {
+		return "", err
+	}
+	rendered := path.Clean(buf.String())
+	if rendered == "." || path.IsAbs(rendered) || rendered == ".." || strings.HasPrefix(rendered, "../") {
+		return "", fmt.Errorf("template file %q renders to the path %q outside of the target directory", name, buf.String())
+	}
+	return rendered, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// postProcess applies all post-processors to the content of a file.
+// The content is returned unchanged if a post-processor fails.
+func (x *extraction) postProcess(path string, content []byte) ([]byte, error) {
+	processed := content
+	for _, pp := range x.postProcessors {
+		c, err := pp.PostProcess(path, processed)
+		if err != nil {
+			return content, err
+		}
+		processed = c
+	}
+	return processed, nil
+}
+
+type postProcessError struct {
+	path string
+	err  error
+}
+
+func (e *postProcessError) Error() string {
+	return fmt.Sprintf("%s: %s", e.path, e.err)
+}
+
 // LoadManifestFile loads the manifest from a ZIP file.
 func (a *ZIPArchiver) LoadManifestFile(src string) ([]byte, error) {
+	return readFile(src, config.ManifestFileName, func(f *zip.File) bool {
+		return filepath.Base(f.Name) == config.ManifestFileName
+	})
+}
+
+// LoadFile loads the raw content of a file from a ZIP file. The name is the path of the file within the archive.
+func (a *ZIPArchiver) LoadFile(src string, name string) ([]byte, error) {
+	return readFile(src, name, func(f *zip.File) bool {
+		return filepath.ToSlash(filepath.Clean(f.Name)) == name
+	})
+}
+
+func readFile(src string, name string, matches func(f *zip.File) bool) ([]byte, error) {
 	r, err := zip.OpenReader(src)
 	if err != nil {
 		return nil, err
@@ -110,18 +330,28 @@ func (a *ZIPArchiver) LoadManifestFile(src string) ([]byte, error) {
 			continue
 		}
 
-		if filepath.Base(f.Name) == manifestFile {
-			rc, err := f.Open()
-			if err != nil {
-				return nil, err
-			}
-			b := bytes.NewBuffer(nil)
-			_, err = io.Copy(b, rc)
-			if err != nil {
-				return nil, err
-			}
-			return b.Bytes(), err
+		if matches(f) {
+			return readZIPFile(f)
 		}
 	}
-	return nil, fmt.Errorf("could not locate %s file", manifestFile)
+	return nil, fmt.Errorf("could not locate %s file", name)
+}
+
+func readZIPFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rc.Close(); err != nil {
+			panic(err)
+		}
+	}()
+
+	b := bytes.NewBuffer(nil)
+	_, err = io.Copy(b, rc)
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
 }
@@ -2,8 +2,10 @@ package archive
 
 import (
 	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/template/config"
 	"github.com/bmuschko/letsgopher/testhelper"
 	"github.com/stretchr/testify/assert"
+	"os"
 	"path/filepath"
 	"testing"
 )
@@ -15,20 +17,20 @@ func TestExtractWithoutTemplateReplacement(t *testing.T) {
 	archive := filepath.Join(tmpHome, "hello-world-1.0.0.zip")
 	archiver := ZIPArchiver{Processor: &TemplateProcessor{}}
 	files := []testhelper.TestFile{
-		{Name: manifestFile, Content: "version: \"1.0.0\""},
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
 		{Name: "file1.txt", Content: "This is a file1"},
 		{Name: "file2.txt", Content: "This is a file2"},
 	}
 	testhelper.CreateZip(t, archive, files)
 	extractedDir := filepath.Join(tmpHome, "new-project")
-	manifestFile := filepath.Join(extractedDir, manifestFile)
+	extractedManifestFile := filepath.Join(extractedDir, config.ManifestFileName)
 	extractedFile1 := filepath.Join(extractedDir, "file1.txt")
 	extractedFile2 := filepath.Join(extractedDir, "file2.txt")
 	err := archiver.Extract(archive, extractedDir, make(map[string]interface{}))
 
 	assert.Nil(t, err)
 	assert.DirExists(t, extractedDir)
-	testhelper.FileNotExists(t, manifestFile)
+	testhelper.FileNotExists(t, extractedManifestFile)
 	assert.FileExists(t, extractedFile1)
 	assert.FileExists(t, extractedFile2)
 
@@ -47,13 +49,13 @@ func TestExtractWithTemplateReplacement(t *testing.T) {
 	archive := filepath.Join(tmpHome, "hello-world-1.0.0.zip")
 	archiver := ZIPArchiver{Processor: &TemplateProcessor{}}
 	files := []testhelper.TestFile{
-		{Name: manifestFile, Content: "version: \"1.0.0\""},
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
 		{Name: "file1.txt", Content: "This is a {( .a }}"},
 		{Name: "file2.txt", Content: "This is a {{ .b }}"},
 	}
 	testhelper.CreateZip(t, archive, files)
 	extractedDir := filepath.Join(tmpHome, "new-project")
-	manifestFile := filepath.Join(extractedDir, manifestFile)
+	extractedManifestFile := filepath.Join(extractedDir, config.ManifestFileName)
 	extractedFile1 := filepath.Join(extractedDir, "file1.txt")
 	extractedFile2 := filepath.Join(extractedDir, "file2.txt")
 	replacements := make(map[string]interface{})
@@ -63,7 +65,7 @@ func TestExtractWithTemplateReplacement(t *testing.T) {
 
 	assert.Nil(t, err)
 	assert.DirExists(t, extractedDir)
-	testhelper.FileNotExists(t, manifestFile)
+	testhelper.FileNotExists(t, extractedManifestFile)
 	assert.FileExists(t, extractedFile1)
 	assert.FileExists(t, extractedFile2)
 
@@ -80,7 +82,7 @@ func TestLoadExistingManifestFile(t *testing.T) {
 	archive := filepath.Join(tmpHome, "hello-world-1.0.0.zip")
 	archiver := ZIPArchiver{Processor: &TemplateProcessor{}}
 	files := []testhelper.TestFile{
-		{Name: manifestFile, Content: "version: \"1.0.0\""},
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
 	}
 	testhelper.CreateZip(t, archive, files)
 	b, err := archiver.LoadManifestFile(archive)
@@ -106,3 +108,346 @@ func TestLoadNonExistentManifestFile(t *testing.T) {
 	assert.Equal(t, "could not locate manifest.yaml file", err.Error())
 	assert.Equal(t, []byte(nil), b)
 }
+
+func TestExtractWithGoFormatter(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archive := filepath.Join(tmpHome, "hello-world-1.0.0.zip")
+	archiver := ZIPArchiver{Processor: &TemplateProcessor{}}
+	files := []testhelper.TestFile{
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
+		{Name: "main.go", Content: "package main\nfunc main() {\n  println( \"{{ .name }}\" )\n}\n"},
+		{Name: "README.md", Content: "func  main( )"},
+	}
+	testhelper.CreateZip(t, archive, files)
+	extractedDir := filepath.Join(tmpHome, "new-project")
+	err := archiver.Extract(archive, extractedDir, map[string]interface{}{"name": "hello"}, &GoFormatter{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "package main\n\nfunc main() {\n\tprintln(\"hello\")\n}\n", testhelper.ReadFile(t, filepath.Join(extractedDir, "main.go")))
+	assert.Equal(t, "func  main( )", testhelper.ReadFile(t, filepath.Join(extractedDir, "README.md")))
+}
+
+func TestExtractWithGoFormatterReportsInvalidGoFiles(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archive := filepath.Join(tmpHome, "hello-world-1.0.0.zip")
+	archiver := ZIPArchiver{Processor: &TemplateProcessor{}}
+	files := []testhelper.TestFile{
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
+		{Name: "main.go", Content: "package main\nfunc main() {"},
+		{Name: "other.go", Content: "package main\nfunc  other() {}\n"},
+	}
+	testhelper.CreateZip(t, archive, files)
+	extractedDir := filepath.Join(tmpHome, "new-project")
+	extractedFile := filepath.Join(extractedDir, "main.go")
+	err := archiver.Extract(archive, extractedDir, make(map[string]interface{}), &GoFormatter{})
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "failed to post-process generated files:")
+	assert.Contains(t, err.Error(), extractedFile+" (template source "+archive+":main.go)")
+	assert.Equal(t, "package main\nfunc main() {", testhelper.ReadFile(t, extractedFile))
+	assert.Equal(t, "package main\n\nfunc other() {}\n", testhelper.ReadFile(t, filepath.Join(extractedDir, "other.go")))
+}
+
+func TestExtractWithModuleRewriter(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archive := filepath.Join(tmpHome, "hello-world-1.0.0.zip")
+	archiver := ZIPArchiver{Processor: &TemplateProcessor{}}
+	files := []testhelper.TestFile{
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
+		{Name: "go.mod", Content: "module github.com/example/placeholder\n\ngo 1.13\n"},
+		{Name: "main.go", Content: "package main\n\nimport \"github.com/example/placeholder/cmd\"\n\nfunc main() {\n\tcmd.Execute()\n}\n"},
+		{Name: "README.md", Content: "github.com/example/placeholder"},
+	}
+	testhelper.CreateZip(t, archive, files)
+	extractedDir := filepath.Join(tmpHome, "new-project")
+	err := archiver.Extract(archive, extractedDir, make(map[string]interface{}), &ModuleRewriter{From: "github.com/example/placeholder", To: "github.com/acme/hello"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "module github.com/acme/hello\n\ngo 1.13\n", testhelper.ReadFile(t, filepath.Join(extractedDir, "go.mod")))
+	assert.Equal(t, "package main\n\nimport \"github.com/acme/hello/cmd\"\n\nfunc main() {\n\tcmd.Execute()\n}\n", testhelper.ReadFile(t, filepath.Join(extractedDir, "main.go")))
+	assert.Equal(t, "github.com/example/placeholder", testhelper.ReadFile(t, filepath.Join(extractedDir, "README.md")))
+}
+
+func TestExtractAppliesPostProcessorsAndPartialsOnlyToSingleExtraction(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	parent := filepath.Join(tmpHome, "service-1.0.0.zip")
+	dependency := filepath.Join(tmpHome, "ci-1.0.0.zip")
+	archiver := ZIPArchiver{Processor: &TemplateProcessor{}}
+	testhelper.CreateZip(t, parent, []testhelper.TestFile{
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
+		{Name: "_partials/license-header.tmpl", Content: "// Copyright ACME"},
+		{Name: "main.go", Content: "{{template \"license-header\" .}}\npackage  main\n"},
+	})
+	testhelper.CreateZip(t, dependency, []testhelper.TestFile{
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
+		{Name: "tools.go", Content: "package  tools\n"},
+		{Name: "ci.go", Content: "{{template \"license-header\" .}}\npackage  ci\n"},
+	})
+	err := archiver.Extract(parent, filepath.Join(tmpHome, "service"), make(map[string]interface{}), &GoFormatter{})
+	assert.Nil(t, err)
+	assert.Equal(t, "// Copyright ACME\npackage main\n", testhelper.ReadFile(t, filepath.Join(tmpHome, "service", "main.go")))
+
+	err = archiver.Extract(dependency, filepath.Join(tmpHome, "ci"), make(map[string]interface{}))
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "template \"license-header\" not defined")
+	assert.Equal(t, "package  tools\n", testhelper.ReadFile(t, filepath.Join(tmpHome, "ci", "tools.go")))
+}
+
+func TestLoadFile(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archive := filepath.Join(tmpHome, "hello-world-1.0.0.zip")
+	archiver := ZIPArchiver{Processor: &TemplateProcessor{}}
+	files := []testhelper.TestFile{
+		{Name: "sub/go.mod", Content: "module github.com/example/placeholder/sub"},
+		{Name: "go.mod", Content: "module github.com/example/placeholder"},
+	}
+	testhelper.CreateZip(t, archive, files)
+	b, err := archiver.LoadFile(archive, "go.mod")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "module github.com/example/placeholder", string(b))
+}
+
+func TestExtractFailsOnConflicts(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archive := filepath.Join(tmpHome, "hello-world-1.0.0.zip")
+	archiver := ZIPArchiver{Processor: &TemplateProcessor{}, Conflicts: FailOnConflicts}
+	files := []testhelper.TestFile{
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
+		{Name: "file1.txt", Content: "This is a file1"},
+		{Name: "file2.txt", Content: "This is a file2"},
+	}
+	testhelper.CreateZip(t, archive, files)
+	extractedDir := filepath.Join(tmpHome, "new-project")
+	existingFile := filepath.Join(extractedDir, "file2.txt")
+	assert.Nil(t, os.MkdirAll(extractedDir, 0755))
+	testhelper.WriteFile(t, existingFile, "existing", 0644)
+	err := archiver.Extract(archive, extractedDir, make(map[string]interface{}))
+
+	assert.NotNil(t, err)
+	assert.Equal(t, &ConflictError{Paths: []string{existingFile}}, err)
+	testhelper.FileNotExists(t, filepath.Join(extractedDir, "file1.txt"))
+	assert.Equal(t, "existing", testhelper.ReadFile(t, existingFile))
+}
+
+func TestCheckConflicts(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archive := filepath.Join(tmpHome, "hello-world-1.0.0.zip")
+	files := []testhelper.TestFile{
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
+		{Name: "file1.txt", Content: "This is a file1"},
+		{Name: "{{ .name }}.txt", Content: "This is a file2"},
+	}
+	testhelper.CreateZip(t, archive, files)
+	extractedDir := filepath.Join(tmpHome, "new-project")
+	existingFile := filepath.Join(extractedDir, "file2.txt")
+	assert.Nil(t, os.MkdirAll(extractedDir, 0755))
+	testhelper.WriteFile(t, existingFile, "existing", 0644)
+	replacements := map[string]interface{}{"name": "file2"}
+
+	archiver := ZIPArchiver{Processor: &TemplateProcessor{}, Conflicts: FailOnConflicts}
+	err := archiver.CheckConflicts([]string{archive}, extractedDir, replacements)
+	assert.Equal(t, &ConflictError{Paths: []string{existingFile}}, err)
+	testhelper.FileNotExists(t, filepath.Join(extractedDir, "file1.txt"))
+
+	archiver.Conflicts = SkipConflicts
+	assert.Nil(t, archiver.CheckConflicts([]string{archive}, extractedDir, replacements))
+}
+
+func TestExtractSkipsConflicts(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archive := filepath.Join(tmpHome, "hello-world-1.0.0.zip")
+	archiver := ZIPArchiver{Processor: &TemplateProcessor{}, Conflicts: SkipConflicts}
+	files := []testhelper.TestFile{
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
+		{Name: "file1.txt", Content: "This is a file1"},
+		{Name: "file2.txt", Content: "This is a file2"},
+	}
+	testhelper.CreateZip(t, archive, files)
+	extractedDir := filepath.Join(tmpHome, "new-project")
+	existingFile := filepath.Join(extractedDir, "file2.txt")
+	assert.Nil(t, os.MkdirAll(extractedDir, 0755))
+	testhelper.WriteFile(t, existingFile, "existing", 0644)
+	err := archiver.Extract(archive, extractedDir, make(map[string]interface{}))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "This is a file1", testhelper.ReadFile(t, filepath.Join(extractedDir, "file1.txt")))
+	assert.Equal(t, "existing", testhelper.ReadFile(t, existingFile))
+}
+
+func TestExtractLayersOverridesFilesOfEarlierLayers(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	parent := filepath.Join(tmpHome, "base-1.0.0.zip")
+	child := filepath.Join(tmpHome, "service-1.0.0.zip")
+	archiver := ZIPArchiver{Processor: &TemplateProcessor{}}
+	testhelper.CreateZip(t, parent, []testhelper.TestFile{
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
+		{Name: "README.md", Content: "base"},
+		{Name: "main.go", Content: "package {{.name}}"},
+	})
+	testhelper.CreateZip(t, child, []testhelper.TestFile{
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
+		{Name: "README.md", Content: "service"},
+		{Name: "service.go", Content: "package service"},
+	})
+	extractedDir := filepath.Join(tmpHome, "new-project")
+	err := archiver.ExtractLayers([]string{parent, child}, extractedDir, map[string]interface{}{"name": "main"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "service", testhelper.ReadFile(t, filepath.Join(extractedDir, "README.md")))
+	assert.Equal(t, "package main", testhelper.ReadFile(t, filepath.Join(extractedDir, "main.go")))
+	assert.Equal(t, "package service", testhelper.ReadFile(t, filepath.Join(extractedDir, "service.go")))
+	testhelper.FileNotExists(t, filepath.Join(extractedDir, config.ManifestFileName))
+}
+
+func TestExtractWithPartials(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	parent := filepath.Join(tmpHome, "base-1.0.0.zip")
+	child := filepath.Join(tmpHome, "service-1.0.0.zip")
+	archiver := ZIPArchiver{Processor: &TemplateProcessor{}}
+	testhelper.CreateZip(t, parent, []testhelper.TestFile{
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
+		{Name: "_partials/license-header.tmpl", Content: "// Licensed to {{.owner}}"},
+		{Name: "_partials/go/package.tmpl", Content: "package {{.name}}"},
+		{Name: "main.go", Content: "{{template \"license-header\" .}}\n{{template \"go/package\" .}}"},
+	})
+	testhelper.CreateZip(t, child, []testhelper.TestFile{
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
+		{Name: "_partials/license-header.tmpl", Content: "// Copyright {{.owner}}"},
+	})
+	extractedDir := filepath.Join(tmpHome, "new-project")
+	err := archiver.ExtractLayers([]string{parent, child}, extractedDir, map[string]interface{}{"owner": "ACME", "name": "main"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "// Copyright ACME\npackage main", testhelper.ReadFile(t, filepath.Join(extractedDir, "main.go")))
+	testhelper.FileNotExists(t, filepath.Join(extractedDir, partialsDir))
+}
+
+func TestExtractWithInvalidPartial(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archive := filepath.Join(tmpHome, "hello-world-1.0.0.zip")
+	archiver := ZIPArchiver{Processor: &TemplateProcessor{}}
+	testhelper.CreateZip(t, archive, []testhelper.TestFile{
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
+		{Name: "_partials/license-header.tmpl", Content: "// Copyright {{.owner"},
+		{Name: "main.go", Content: "package main"},
+	})
+	extractedDir := filepath.Join(tmpHome, "new-project")
+	err := archiver.Extract(archive, extractedDir, make(map[string]interface{}))
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "failed to parse partial \"license-header\"")
+	assert.Contains(t, err.Error(), "(template source "+archive+":_partials/license-header.tmpl)")
+	testhelper.FileNotExists(t, filepath.Join(extractedDir, "main.go"))
+}
+
+func TestExtractWithMissingPartial(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archive := filepath.Join(tmpHome, "hello-world-1.0.0.zip")
+	archiver := ZIPArchiver{Processor: &TemplateProcessor{}}
+	testhelper.CreateZip(t, archive, []testhelper.TestFile{
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
+		{Name: "main.go", Content: "{{template \"license-header\" .}}\npackage main"},
+	})
+	extractedDir := filepath.Join(tmpHome, "new-project")
+	err := archiver.Extract(archive, extractedDir, make(map[string]interface{}))
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "template \"license-header\" not defined")
+	assert.Contains(t, err.Error(), "(template source "+archive+":main.go)")
+	testhelper.FileNotExists(t, filepath.Join(extractedDir, "main.go"))
+}
+
+func TestExtractWithBrokenPartial(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archive := filepath.Join(tmpHome, "hello-world-1.0.0.zip")
+	archiver := ZIPArchiver{Processor: &TemplateProcessor{}}
+	testhelper.CreateZip(t, archive, []testhelper.TestFile{
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
+		{Name: "_partials/license-header.tmpl", Content: "// Copyright {{template \"owner\" .}}"},
+		{Name: "main.go", Content: "{{template \"license-header\" .}}\npackage main"},
+	})
+	extractedDir := filepath.Join(tmpHome, "new-project")
+	err := archiver.Extract(archive, extractedDir, make(map[string]interface{}))
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "template \"owner\" not defined")
+	assert.Contains(t, err.Error(), "(template source "+archive+":main.go)")
+	testhelper.FileNotExists(t, filepath.Join(extractedDir, "main.go"))
+}
+
+func TestExtractWithPlaceholdersInPaths(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archive := filepath.Join(tmpHome, "hello-world-1.0.0.zip")
+	archiver := ZIPArchiver{Processor: &TemplateProcessor{}}
+	testhelper.CreateZip(t, archive, []testhelper.TestFile{
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
+		{Name: "cmd/{{ .appName }}/main.go", Content: "package main"},
+		{Name: "{{ .appName }}.yaml", Content: "name: {{ .appName }}"},
+	})
+	extractedDir := filepath.Join(tmpHome, "new-project")
+	err := archiver.Extract(archive, extractedDir, map[string]interface{}{"appName": "service"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "package main", testhelper.ReadFile(t, filepath.Join(extractedDir, "cmd", "service", "main.go")))
+	assert.Equal(t, "name: service", testhelper.ReadFile(t, filepath.Join(extractedDir, "service.yaml")))
+}
+
+func TestExtractWithPlaceholderRenderingOutsideOfTargetDir(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archive := filepath.Join(tmpHome, "hello-world-1.0.0.zip")
+	archiver := ZIPArchiver{Processor: &TemplateProcessor{}}
+	testhelper.CreateZip(t, archive, []testhelper.TestFile{
+		{Name: config.ManifestFileName, Content: "version: \"1.0.0\""},
+		{Name: "{{ .dir }}/main.go", Content: "package main"},
+	})
+	extractedDir := filepath.Join(tmpHome, "new-project")
+	err := archiver.Extract(archive, extractedDir, map[string]interface{}{"dir": ".."})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "template file \"{{ .dir }}/main.go\" renders to the path \"../main.go\" outside of the target directory", err.Error())
+	testhelper.FileNotExists(t, filepath.Join(tmpHome, "main.go"))
+}
+
+func TestParseConflictPolicy(t *testing.T) {
+	policy, err := ParseConflictPolicy("skip")
+
+	assert.Nil(t, err)
+	assert.Equal(t, SkipConflicts, policy)
+
+	_, err = ParseConflictPolicy("merge")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "unknown conflict policy \"merge\", expected one of [fail, skip, overwrite]", err.Error())
+}
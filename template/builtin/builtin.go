@@ -0,0 +1,69 @@
+package builtin
+
+import (
+	"bytes"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/bmuschko/letsgopher/template/expression"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/template"
+)
+
+// Template is a template compiled into the letsgopher binary.
+// Its files use the delimiters [[ and ]] for actions so that they can contain the {{ and }} of the generated template files.
+type Template struct {
+	Manifest string
+	Files    []*File
+}
+
+// File is a file of a built-in template. The name is the slash-separated path relative to the target directory.
+type File struct {
+	Name    string
+	Content string
+	Mode    os.FileMode
+}
+
+// LoadManifest parses and validates the manifest of the built-in template.
+func (t *Template) LoadManifest() (*config.ManifestFile, error) {
+	m, err := config.LoadManifestData([]byte(t.Manifest))
+	if err != nil {
+		return nil, err
+	}
+	err = config.ValidateManifest(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Generate renders the files of the built-in template into the target directory.
+func (t *Template) Generate(targetDir string, replacements map[string]interface{}) error {
+	for _, f := range t.Files {
+		tmpl, err := template.New(f.Name).Delims("[[", "]]").Funcs(expression.FuncMap()).Funcs(template.FuncMap{"quote": strconv.Quote}).Parse(f.Content)
+		if err != nil {
+			return err
+		}
+		buf := bytes.NewBuffer(nil)
+		err = tmpl.Execute(buf, replacements)
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(targetDir, filepath.FromSlash(f.Name))
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			return err
+		}
+		mode := f.Mode
+		if mode == 0 {
+			mode = 0644
+		}
+		err = ioutil.WriteFile(path, buf.Bytes(), mode)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
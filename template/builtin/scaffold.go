@@ -0,0 +1,210 @@
+package builtin
+
+// Scaffold is the built-in template generating the skeleton of a new template.
+// The skeleton contains a documented manifest declaring a parameter of every type, example files using the parameters,
+// an ignore file and a test case rendering the example files with an answers file.
+var Scaffold = &Template{
+	Manifest: `version: "1.0.0"
+parameters:
+  - name: "name"
+    type: "string"
+    description: "The name of the template"
+  - name: "description"
+    prompt: "Please provide a short description of the template"
+    type: "string"
+    defaultValue: "A project template for Go"
+  - name: "author"
+    prompt: "Please provide the name of the template author"
+    type: "string"
+    defaultValue: "{{ gitUserName }}"
+`,
+	Files: []*File{
+		{Name: "manifest.yaml", Content: scaffoldManifest},
+		{Name: ".letsgopherignore", Content: scaffoldIgnore},
+		{Name: "go.mod", Content: scaffoldGoMod},
+		{Name: "main.go", Content: scaffoldMain},
+		{Name: "README.md", Content: scaffoldReadme},
+		{Name: "tests/default/answers.yaml", Content: scaffoldAnswers},
+		{Name: "tests/default/expected/go.mod", Content: scaffoldExpectedGoMod},
+		{Name: "tests/default/expected/main.go", Content: scaffoldExpectedMain},
+		{Name: "tests/default/expected/README.md", Content: scaffoldExpectedReadme},
+	},
+}
+
+const scaffoldManifest = `# The manifest describes the template and declares the parameters requested when generating a project from it.
+# Run "letsgopher manifest schema" to obtain the JSON schema of this file for validation and autocompletion in your editor.
+
+# The version of the manifest format. Version 2.0.0 allows for describing the template with metadata.
+version: "2.0.0"
+
+# Metadata shown by the commands "template list" and "template inspect".
+name: [[ quote .name ]]
+description: [[ quote .description ]]
+authors:
+  - [[ quote .author ]]
+tags: ["example"]
+
+# Parameters are requested in the declared order. Files of the template refer to the value of a parameter
+# with a Go template action, e.g. {{ .module }}. Every parameter needs to provide a name and a type.
+# The following parameters demonstrate all supported types.
+parameters:
+  # Module paths need to follow the rules of Go modules.
+  - name: "module"
+    prompt: "Please provide the module path"
+    type: "modulePath"
+    description: "The module path declared in go.mod"
+    defaultValue: "github.com/example/[[ .name ]]"
+  # Strings can be restricted to a list of allowed values with enum.
+  - name: "message"
+    prompt: "Please select the message printed by the program"
+    type: "string"
+    enum: ["Hello World!", "Let's get started"]
+  # Integers can be restricted to a range with min and max.
+  - name: "repetitions"
+    prompt: "How often should the message be printed?"
+    type: "integer"
+    defaultValue: "1"
+    min: 1
+    max: 10
+  # Booleans accept the values true and false.
+  - name: "verbose"
+    prompt: "Should the program print additional information?"
+    type: "boolean"
+    defaultValue: "false"
+  # Parameters with a condition are only requested if the condition is true, otherwise the fallback value is used.
+  - name: "logLevel"
+    prompt: "Please select the log level"
+    type: "string"
+    enum: ["debug", "info"]
+    when: ".verbose"
+    fallback: "info"
+  # Lists are entered as comma-separated values.
+  - name: "features"
+    prompt: "Please list the features of the project"
+    type: "list"
+  # Maps are entered as comma-separated key=value pairs.
+  - name: "labels"
+    prompt: "Please provide labels as key=value pairs"
+    type: "map"
+  # Paths are cleaned, e.g. config/../etc becomes etc.
+  - name: "configDir"
+    prompt: "Please provide the configuration directory"
+    type: "path"
+    defaultValue: "config"
+  # URLs need to provide a scheme and a host.
+  - name: "homepage"
+    prompt: "Please provide the homepage of the project"
+    type: "url"
+  # Email addresses need to be plain addresses without a name.
+  - name: "maintainer"
+    prompt: "Please provide the email address of the maintainer"
+    type: "email"
+  # Semantic versions follow https://semver.org.
+  - name: "version"
+    prompt: "Please provide the initial version of the project"
+    type: "semver"
+    defaultValue: "0.1.0"
+  # Computed parameters are derived from other parameters and never requested.
+  - name: "binaryName"
+    type: "string"
+    computed: "kebabCase (base .module)"
+`
+
+const scaffoldIgnore = `# Files excluded from the archive created by "letsgopher template package", following the syntax of .gitignore files.
+# The test cases are only needed for developing the template.
+/tests/
+`
+
+const scaffoldGoMod = `module {{ .module }}
+
+go 1.13
+`
+
+const scaffoldMain = `package main
+
+import "fmt"
+
+// version is the version of {{ .binaryName }}.
+const version = "{{ .version }}"
+
+func main() {
+	for i := 0; i < {{ .repetitions }}; i++ {
+		fmt.Println({{ printf "%q" .message }})
+	}
+{{- if .verbose }}
+	fmt.Println("log level: {{ .logLevel }}")
+{{- end }}
+	fmt.Println("version:", version)
+}
+`
+
+const scaffoldReadme = `# {{ .binaryName }}
+
+[[ .description ]]
+
+Maintained by {{ .maintainer }}, see {{ .homepage }} for details.
+Configuration files are read from the directory {{ .configDir }}.
+
+## Features
+{{ range .features }}
+- {{ . }}
+{{- end }}
+
+## Labels
+{{ range $key, $value := .labels }}
+- {{ $key }}: {{ $value }}
+{{- end }}
+`
+
+const scaffoldAnswers = `# Parameter values used to render the test case. The rendered files are compared with the expected directory.
+module: "github.com/example/[[ .name ]]"
+message: "Hello World!"
+repetitions: 3
+verbose: true
+logLevel: "debug"
+features: ["logging", "metrics"]
+labels:
+  team: "platform"
+configDir: "config"
+homepage: "https://example.com/[[ .name ]]"
+maintainer: "maintainer@example.com"
+version: "1.0.0"
+`
+
+const scaffoldExpectedGoMod = `module github.com/example/[[ .name ]]
+
+go 1.13
+`
+
+const scaffoldExpectedMain = `package main
+
+import "fmt"
+
+// version is the version of [[ kebabCase .name ]].
+const version = "1.0.0"
+
+func main() {
+	for i := 0; i < 3; i++ {
+		fmt.Println("Hello World!")
+	}
+	fmt.Println("log level: debug")
+	fmt.Println("version:", version)
+}
+`
+
+const scaffoldExpectedReadme = `# [[ kebabCase .name ]]
+
+[[ .description ]]
+
+Maintained by maintainer@example.com, see https://example.com/[[ .name ]] for details.
+Configuration files are read from the directory config.
+
+## Features
+
+- logging
+- metrics
+
+## Labels
+
+- team: platform
+`
@@ -0,0 +1,77 @@
+package builtin
+
+import (
+	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/template/archive"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/bmuschko/letsgopher/template/lint"
+	"github.com/bmuschko/letsgopher/template/param"
+	"github.com/bmuschko/letsgopher/testhelper"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestScaffoldManifest(t *testing.T) {
+	m, err := Scaffold.LoadManifest()
+
+	assert.Nil(t, err)
+	assert.Len(t, m.Parameters, 3)
+}
+
+func TestScaffoldRendersExpectedFilesOfTestCase(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	dir := filepath.Join(tmpDir, "hello-world")
+	err := Scaffold.Generate(dir, map[string]interface{}{"name": "hello-world", "description": "A \"friendly\" greeter", "author": "Jane Doe"})
+	assert.Nil(t, err)
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "manifest.yaml"))
+	assert.Nil(t, err)
+	m, err := config.LoadManifestData(b)
+	assert.Nil(t, err)
+	assert.Nil(t, config.ValidateManifest(m))
+	assert.Equal(t, "hello-world", m.Metadata.Name)
+	assert.Equal(t, "A \"friendly\" greeter", m.Metadata.Description)
+	files, err := lint.LoadDir(dir)
+	assert.Nil(t, err)
+	assert.Empty(t, (&lint.Linter{}).Lint(files))
+
+	answers, err := config.LoadAnswersFile(filepath.Join(dir, "tests", "default", "answers.yaml"))
+	assert.Nil(t, err)
+	r, err := (&param.Resolver{Answers: answers}).Resolve(m.Parameters)
+	assert.Nil(t, err)
+	archiveZip := filepath.Join(tmpDir, "hello-world-0.1.0.zip")
+	assert.Nil(t, archive.Package(dir, archiveZip, archive.ZIPFormat))
+	projectDir := filepath.Join(tmpDir, "project")
+	assert.Nil(t, (&archive.ZIPArchiver{Processor: &archive.TemplateProcessor{}}).Extract(archiveZip, projectDir, r))
+
+	expectedDir := filepath.Join(dir, "tests", "default", "expected")
+	expected := relativeFiles(t, expectedDir)
+	assert.Equal(t, []string{"README.md", "go.mod", "main.go"}, expected)
+	assert.Equal(t, expected, relativeFiles(t, projectDir))
+	for _, f := range expected {
+		assert.Equal(t, testhelper.ReadFile(t, filepath.Join(expectedDir, f)), testhelper.ReadFile(t, filepath.Join(projectDir, f)), f)
+	}
+}
+
+func relativeFiles(t *testing.T, dir string) []string {
+	files := []string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		files = append(files, filepath.ToSlash(rel))
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Failed to list files of %s. Reason: %s", dir, err)
+	}
+	sort.Strings(files)
+	return files
+}
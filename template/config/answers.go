@@ -0,0 +1,34 @@
+package config
+
+import (
+	"github.com/ghodss/yaml"
+	"io/ioutil"
+)
+
+// LoadAnswersFile loads a YAML file mapping parameter names to values.
+func LoadAnswersFile(path string) (map[string]string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return LoadAnswersData(b)
+}
+
+// LoadAnswersData unmarshals YAML content mapping parameter names to values.
+// List and map values are converted into the textual representation understood by Parameter.ParseValue.
+func LoadAnswersData(b []byte) (map[string]string, error) {
+	raw := make(map[string]interface{})
+	err := yaml.Unmarshal(b, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	answers := make(map[string]string)
+	for k, v := range raw {
+		if v == nil {
+			continue
+		}
+		answers[k] = FormatValue(v)
+	}
+	return answers, nil
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/testhelper"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadAnswersDataForScalarValues(t *testing.T) {
+	content := []byte(`module: "github.com/example/hello"
+port: 8080
+debug: true
+empty:`)
+	answers, err := LoadAnswersData(content)
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"module": "github.com/example/hello", "port": "8080", "debug": "true"}, answers)
+}
+
+func TestLoadAnswersDataForIncorrectDefinition(t *testing.T) {
+	answers, err := LoadAnswersData([]byte("test"))
+
+	assert.Nil(t, answers)
+	assert.NotNil(t, err)
+}
+
+func TestLoadAnswersFile(t *testing.T) {
+	tmpHome := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	f := filepath.Join(tmpHome, "answers.yaml")
+	testhelper.WriteFile(t, f, `module: hello`, 0644)
+	answers, err := LoadAnswersFile(f)
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"module": "hello"}, answers)
+}
+
+func TestLoadAnswersDataForListAndMapValues(t *testing.T) {
+	content := []byte(`services: ["api", "worker"]
+labels:
+  team: core
+  tier: backend`)
+	answers, err := LoadAnswersData(content)
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"services": "api,worker", "labels": "team=core,tier=backend"}, answers)
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/bmuschko/letsgopher/template/expression"
+	"github.com/bmuschko/letsgopher/template/version"
+	"path"
+	"strings"
+)
+
+// Dependency represents another template generated together with a template.
+// Version is a version range the installed template needs to satisfy, e.g. ">=1.2 <2".
+// The files of the dependency are generated into Directory relative to the project directory.
+// Parameters map parameter names of the dependency to text rendered with the parameter values of the template.
+// Source is the URL of a template archive installed if no installed template satisfies the version range.
+type Dependency struct {
+	Name       string            `json:"name"`
+	Version    string            `json:"version"`
+	Directory  string            `json:"directory,omitempty"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+	Source     string            `json:"source,omitempty"`
+}
+
+// Validate checks the definition of a dependency.
+func (d *Dependency) Validate() error {
+	if d.Name == "" {
+		return errors.New("dependency needs to provide the name of a template")
+	}
+	if d.Version == "" {
+		return fmt.Errorf("dependency %q needs to provide a version range", d.Name)
+	}
+	_, err := version.ParseRange(d.Version)
+	if err != nil {
+		return fmt.Errorf("dependency %q defines an invalid version range: %s", d.Name, err)
+	}
+	if d.Directory != "" {
+		dir := path.Clean(d.Directory)
+		if path.IsAbs(dir) || dir == ".." || strings.HasPrefix(dir, "../") {
+			return fmt.Errorf("dependency %q needs to use a directory within the project directory", d.Name)
+		}
+	}
+	for name, text := range d.Parameters {
+		err := expression.ValidateTemplate(text)
+		if err != nil {
+			return fmt.Errorf("dependency %q maps parameter %q to an invalid template: %s", d.Name, name, err)
+		}
+	}
+	return nil
+}
+
+// MapParameters renders the parameter values passed on to the dependency.
+func (d *Dependency) MapParameters(replacements map[string]interface{}) (map[string][]string, error) {
+	values := make(map[string][]string)
+	for name, text := range d.Parameters {
+		v, err := expression.Render(text, replacements)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map parameter %q of dependency %q: %s", name, d.Name, err)
+		}
+		values[name] = []string{v}
+	}
+	return values, nil
+}
+
+// Allows determines whether a template version lies within the version range of the dependency.
+func (d *Dependency) Allows(templateVersion string) (bool, error) {
+	return version.Satisfies(templateVersion, d.Version)
+}
+
+func validateDependencies(dependencies []*Dependency) error {
+	names := make(map[string]bool)
+	for i, d := range dependencies {
+		err := d.Validate()
+		if err != nil {
+			return atPath(err, "dependencies", i)
+		}
+		if names[d.Name] {
+			return atPath(fmt.Errorf("dependency %q is declared more than once", d.Name), "dependencies", i, "name")
+		}
+		names[d.Name] = true
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLoadManifestDataWithDependencies(t *testing.T) {
+	manifestFile, err := LoadManifestData([]byte(`version: "1.0.0"
+dependencies:
+  - name: "ci"
+    version: ">=1.2 <2"
+    directory: ".github"
+    parameters:
+      project: "{{ .name }}"
+    source: "https://my.repo.com/ci-1.2.0.zip"`))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*Dependency{{
+		Name:       "ci",
+		Version:    ">=1.2 <2",
+		Directory:  ".github",
+		Parameters: map[string]string{"project": "{{ .name }}"},
+		Source:     "https://my.repo.com/ci-1.2.0.zip",
+	}}, manifestFile.Dependencies)
+	assert.Nil(t, ValidateManifest(manifestFile))
+}
+
+func TestValidateManifestWithInvalidDependencies(t *testing.T) {
+	dependencies := []struct {
+		dependencies []*Dependency
+		expected     string
+	}{
+		{[]*Dependency{{Version: "1.0.0"}}, "dependency needs to provide the name of a template"},
+		{[]*Dependency{{Name: "ci"}}, "dependency \"ci\" needs to provide a version range"},
+		{[]*Dependency{{Name: "ci", Version: ">=one"}}, "dependency \"ci\" defines an invalid version range"},
+		{[]*Dependency{{Name: "ci", Version: "1.x", Directory: "../ci"}}, "dependency \"ci\" needs to use a directory within the project directory"},
+		{[]*Dependency{{Name: "ci", Version: "1.x", Directory: "/ci"}}, "dependency \"ci\" needs to use a directory within the project directory"},
+		{[]*Dependency{{Name: "ci", Version: "1.x", Parameters: map[string]string{"project": "{{ .name"}}}, "dependency \"ci\" maps parameter \"project\" to an invalid template"},
+		{[]*Dependency{{Name: "ci", Version: "1.x"}, {Name: "ci", Version: "2.x"}}, "dependency \"ci\" is declared more than once"},
+	}
+
+	for _, d := range dependencies {
+		t.Run(d.expected, func(t *testing.T) {
+			err := ValidateManifest(&ManifestFile{Version: "1.0.0", Dependencies: d.dependencies})
+
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), d.expected)
+		})
+	}
+}
+
+func TestMapDependencyParameters(t *testing.T) {
+	d := &Dependency{Name: "ci", Version: "1.x", Parameters: map[string]string{"project": "{{ .name }}-ci", "owner": "platform"}}
+	params, err := d.MapParameters(map[string]interface{}{"name": "hello"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{"project": {"hello-ci"}, "owner": {"platform"}}, params)
+}
+
+func TestInheritReplacesDependenciesWithSameName(t *testing.T) {
+	parent := &ManifestFile{Version: "1.0.0", Dependencies: []*Dependency{{Name: "ci", Version: "1.x"}, {Name: "deploy", Version: "2.x"}}}
+	child := &ManifestFile{Version: "1.0.0", Dependencies: []*Dependency{{Name: "ci", Version: "2.x"}}}
+	merged := child.Inherit(parent)
+
+	assert.Equal(t, []*Dependency{{Name: "deploy", Version: "2.x"}, {Name: "ci", Version: "2.x"}}, merged.Dependencies)
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ParentTemplate identifies the installed template a template extends.
+type ParentTemplate struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// String returns the name and version of the parent template.
+func (p *ParentTemplate) String() string {
+	return fmt.Sprintf("%s %s", p.Name, p.Version)
+}
+
+// Inherit merges the manifest of a parent template into the manifest of a child template.
+// The descriptive metadata of the child is kept as is.
+// Parameters of the child replace parameters with the same name of the parent, all other child parameters follow the parent parameters.
+// Hooks of the parent run before the hooks of the child. Options enabled by either template stay enabled.
+// Dependencies of the child replace dependencies with the same name of the parent.
+func (m *ManifestFile) Inherit(parent *ManifestFile) *ManifestFile {
+	merged := &ManifestFile{
+		Version:         m.Version,
+		Metadata:        m.Metadata,
+		FormatGoFiles:   m.FormatGoFiles || parent.FormatGoFiles,
+		GoModule:        m.GoModule || parent.GoModule,
+		ModuleParameter: m.ModuleParameter,
+	}
+	if merged.ModuleParameter == "" {
+		merged.ModuleParameter = parent.ModuleParameter
+	}
+
+	overridden := make(map[string]bool)
+	for _, p := range m.Parameters {
+		overridden[p.Name] = true
+	}
+	inherited := make(map[string]bool)
+	for _, p := range parent.Parameters {
+		if overridden[p.Name] {
+			p = m.parameter(p.Name)
+			inherited[p.Name] = true
+		}
+		merged.Parameters = append(merged.Parameters, p)
+	}
+	for _, p := range m.Parameters {
+		if !inherited[p.Name] {
+			merged.Parameters = append(merged.Parameters, p)
+		}
+	}
+
+	for _, d := range parent.Dependencies {
+		if m.dependency(d.Name) == nil {
+			merged.Dependencies = append(merged.Dependencies, d)
+		}
+	}
+	merged.Dependencies = append(merged.Dependencies, m.Dependencies...)
+
+	merged.Hooks.PreGenerate = append(append([]*Hook{}, parent.Hooks.PreGenerate...), m.Hooks.PreGenerate...)
+	merged.Hooks.PostGenerate = append(append([]*Hook{}, parent.Hooks.PostGenerate...), m.Hooks.PostGenerate...)
+	return merged
+}
+
+func (m *ManifestFile) parameter(name string) *Parameter {
+	for _, p := range m.Parameters {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func (m *ManifestFile) dependency(name string) *Dependency {
+	for _, d := range m.Dependencies {
+		if d.Name == name {
+			return d
+		}
+	}
+	return nil
+}
+
+// Validate checks that the parent template is fully identified.
+func (p *ParentTemplate) Validate() error {
+	if p.Name == "" || p.Version == "" {
+		return errors.New("manifest needs to provide the name and version of the extended template")
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLoadManifestDataWithExtends(t *testing.T) {
+	manifestFile, err := LoadManifestData([]byte(`version: "1.0.0"
+extends:
+  name: "go-service"
+  version: "2.1.0"`))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &ParentTemplate{Name: "go-service", Version: "2.1.0"}, manifestFile.Extends)
+	assert.Equal(t, "go-service 2.1.0", manifestFile.Extends.String())
+}
+
+func TestValidateManifestWithIncompleteExtends(t *testing.T) {
+	manifestFile := &ManifestFile{Version: "1.0.0", Extends: &ParentTemplate{Name: "go-service"}}
+	err := ValidateManifest(manifestFile)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "/extends: manifest needs to provide the name and version of the extended template", err.Error())
+}
+
+func TestInheritMergesParameters(t *testing.T) {
+	parent := &ManifestFile{
+		Version: "1.0.0",
+		Parameters: []*Parameter{
+			{Name: "module", Type: StringType},
+			{Name: "port", Type: IntegerType, DefaultValue: "8080"},
+		},
+	}
+	child := &ManifestFile{
+		Version: "1.0.0",
+		Extends: &ParentTemplate{Name: "go-service", Version: "1.0.0"},
+		Parameters: []*Parameter{
+			{Name: "database", Type: StringType},
+			{Name: "port", Type: IntegerType, DefaultValue: "9090"},
+		},
+	}
+	merged := child.Inherit(parent)
+
+	assert.Equal(t, []*Parameter{
+		{Name: "module", Type: StringType},
+		{Name: "port", Type: IntegerType, DefaultValue: "9090"},
+		{Name: "database", Type: StringType},
+	}, merged.Parameters)
+	assert.Nil(t, merged.Extends)
+}
+
+func TestInheritMergesHooksAndOptions(t *testing.T) {
+	parent := &ManifestFile{
+		Version:         "1.0.0",
+		GoModule:        true,
+		ModuleParameter: "path",
+		Hooks: Hooks{
+			PreGenerate:  []*Hook{{Name: "parent-pre", Check: "true"}},
+			PostGenerate: []*Hook{{Name: "parent-post", Command: []string{"go", "mod", "tidy"}}},
+		},
+	}
+	child := &ManifestFile{
+		Version:       "1.0.0",
+		FormatGoFiles: true,
+		Hooks: Hooks{
+			PostGenerate: []*Hook{{Name: "child-post", Command: []string{"git", "init"}}},
+		},
+	}
+	merged := child.Inherit(parent)
+
+	assert.True(t, merged.GoModule)
+	assert.True(t, merged.FormatGoFiles)
+	assert.Equal(t, "path", merged.ModuleParameter)
+	assert.Equal(t, []*Hook{{Name: "parent-pre", Check: "true"}}, merged.Hooks.PreGenerate)
+	assert.Equal(t, []*Hook{
+		{Name: "parent-post", Command: []string{"go", "mod", "tidy"}},
+		{Name: "child-post", Command: []string{"git", "init"}},
+	}, merged.Hooks.PostGenerate)
+}
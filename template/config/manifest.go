@@ -4,12 +4,18 @@ import (
 	"errors"
 	"fmt"
 	"github.com/blang/semver"
+	"github.com/bmuschko/letsgopher/template/expression"
 	"github.com/ghodss/yaml"
-	"strconv"
+	"path/filepath"
+	"strings"
 )
 
+// ManifestFileName is the name of the file describing a template.
+const ManifestFileName = "manifest.yaml"
+
 const (
-	maxCompatManifestVersion = "1.0.0"
+	maxCompatManifestVersion = "2.0.0"
+	defaultModuleParameter   = "module"
 
 	// StringType represents the representation of a string parameter type.
 	StringType = "string"
@@ -19,12 +25,66 @@ const (
 
 	// BooleanType represents the representation of a boolean parameter type.
 	BooleanType = "boolean"
+
+	// ListType represents the representation of a list of strings parameter type.
+	ListType = "list"
+
+	// MapType represents the representation of a map of strings parameter type.
+	MapType = "map"
+
+	// PathType represents the representation of a file system path parameter type.
+	PathType = "path"
+
+	// URLType represents the representation of a URL parameter type.
+	URLType = "url"
+
+	// EmailType represents the representation of an email address parameter type.
+	EmailType = "email"
+
+	// SemVerType represents the representation of a semantic version parameter type.
+	SemVerType = "semver"
+
+	// ModulePathType represents the representation of a Go module path parameter type.
+	ModulePathType = "modulePath"
 )
 
+var parameterTypes = []string{StringType, IntegerType, BooleanType, ListType, MapType, PathType, URLType, EmailType, SemVerType, ModulePathType}
+
 // ManifestFile represents a template's metadata.
+// Manifests of version 2.0.0 or higher can describe the template with additional metadata.
 type ManifestFile struct {
-	Version    string       `json:"version"`
-	Parameters []*Parameter `json:"parameters"`
+	Version string `json:"version"`
+	Metadata
+
+	Parameters    []*Parameter `json:"parameters"`
+	Hooks         Hooks        `json:"hooks,omitempty"`
+	FormatGoFiles bool         `json:"formatGoFiles,omitempty"`
+
+	GoModule        bool   `json:"goModule,omitempty"`
+	ModuleParameter string `json:"moduleParameter,omitempty"`
+
+	Extends      *ParentTemplate `json:"extends,omitempty"`
+	Dependencies []*Dependency   `json:"dependencies,omitempty"`
+
+	source []byte
+}
+
+// Hooks represents commands executed at specific points of the project generation.
+type Hooks struct {
+	PreGenerate  []*Hook `json:"preGenerate,omitempty"`
+	PostGenerate []*Hook `json:"postGenerate,omitempty"`
+}
+
+// Hook represents a command or check defined as part of a template's metadata.
+// Arguments and working directory may use template actions rendered with the parameter values.
+// A check is an expression which has to be true for the project generation to proceed.
+type Hook struct {
+	Name       string   `json:"name,omitempty"`
+	Command    []string `json:"command,omitempty"`
+	Check      string   `json:"check,omitempty"`
+	Message    string   `json:"message,omitempty"`
+	When       string   `json:"when,omitempty"`
+	WorkingDir string   `json:"workingDir,omitempty"`
 }
 
 // Parameter represents a parameter defined as part of a template's metadata.
@@ -35,21 +95,58 @@ type Parameter struct {
 	Enum         []string `json:"enum"`
 	Description  string   `json:"description"`
 	DefaultValue string   `json:"defaultValue"`
+	Env          string   `json:"env"`
+	When         string   `json:"when,omitempty"`
+	Fallback     string   `json:"fallback,omitempty"`
+	Computed     string   `json:"computed,omitempty"`
+	Secret       bool     `json:"secret,omitempty"`
+
+	Pattern           string `json:"pattern,omitempty"`
+	MinLength         *int   `json:"minLength,omitempty"`
+	MaxLength         *int   `json:"maxLength,omitempty"`
+	Min               *int   `json:"min,omitempty"`
+	Max               *int   `json:"max,omitempty"`
+	ValidationMessage string `json:"validationMessage,omitempty"`
 }
 
 // LoadManifestData unmarshals YAML content into a ManifestFile.
+// The content is retained to report the lines of invalid elements.
 func LoadManifestData(b []byte) (*ManifestFile, error) {
 	m := &ManifestFile{}
 	err := yaml.Unmarshal(b, m)
 	if err != nil {
 		return nil, err
 	}
+	m.source = b
 	return m, nil
 }
 
+// Line determines the line of the element addressed by a JSON pointer, e.g. /parameters/0.
+// Returns 0 if the manifest hasn't been loaded with LoadManifestData.
+func (m *ManifestFile) Line(pointer string) int {
+	if m.source == nil {
+		return 0
+	}
+	return lineOf(m.source, pointer)
+}
+
 // ValidateManifest validates the expected YAML structure of a manifest.
+// Errors are reported as *ManifestError pointing to the invalid element. The line of the element is
+// included for manifests loaded with LoadManifestData.
 func ValidateManifest(m *ManifestFile) error {
+	err := m.validate()
+	if me, ok := err.(*ManifestError); ok && m.source != nil {
+		me.Line = lineOf(m.source, me.Path)
+	}
+	return err
+}
+
+func (m *ManifestFile) validate() error {
 	err := validateManifestVersion(m.Version)
+	if err != nil {
+		return atPath(err, "version")
+	}
+	err = validateMetadata(m.Version, &m.Metadata)
 	if err != nil {
 		return err
 	}
@@ -57,7 +154,25 @@ func ValidateManifest(m *ManifestFile) error {
 	if err != nil {
 		return err
 	}
-	return nil
+	err = validateGoModule(m)
+	if err != nil {
+		return err
+	}
+	if m.Extends != nil {
+		err = m.Extends.Validate()
+		if err != nil {
+			return atPath(err, "extends")
+		}
+	}
+	err = validateDependencies(m.Dependencies)
+	if err != nil {
+		return err
+	}
+	err = validatePreGenerateHooks(m.Hooks.PreGenerate)
+	if err != nil {
+		return err
+	}
+	return validatePostGenerateHooks(m.Hooks.PostGenerate)
 }
 
 func validateManifestVersion(version string) error {
@@ -83,37 +198,213 @@ func validateManifestVersion(version string) error {
 }
 
 func validateManifestParams(params []*Parameter) error {
-	for _, p := range params {
-		if p.Type == "" {
-			return errors.New("every parameter defined in manifest needs to provide a type")
-		}
-		if p.Type == IntegerType {
-			if p.DefaultValue != "" {
-				_, err := strconv.Atoi(p.DefaultValue)
-				if err != nil {
-					return err
-				}
-			}
-			if p.Enum != nil {
-				for _, e := range p.Enum {
-					_, err := strconv.Atoi(e)
-					if err != nil {
-						return err
-					}
-				}
+	for i, p := range params {
+		err := validateParameter(p)
+		if err != nil {
+			return atPath(err, "parameters", i)
+		}
+	}
+	return nil
+}
+
+func validateParameter(p *Parameter) error {
+	if p.Type == "" {
+		return atPath(errors.New("every parameter defined in manifest needs to provide a type"), "type")
+	}
+	if !isParameterType(p.Type) {
+		return atPath(fmt.Errorf("unknown parameter type %s", p.Type), "type")
+	}
+	err := validateRules(p)
+	if err != nil {
+		return err
+	}
+	if expression.IsTemplate(p.DefaultValue) {
+		err := expression.ValidateTemplate(p.DefaultValue)
+		if err != nil {
+			return atPath(fmt.Errorf("parameter %q defines an invalid default value template: %s", p.Name, err), "defaultValue")
+		}
+	} else if p.DefaultValue != "" {
+		value, err := p.ParseValue(p.DefaultValue)
+		if err != nil {
+			return atPath(err, "defaultValue")
+		}
+		err = p.Validate(value)
+		if err != nil {
+			return atPath(fmt.Errorf("default value of parameter %q is invalid: %s", p.Name, err), "defaultValue")
+		}
+	}
+	if p.Enum != nil {
+		err := validateEnum(p)
+		if err != nil {
+			return atPath(err, "enum")
+		}
+	}
+	err = validateCondition(p)
+	if err != nil {
+		return err
+	}
+	err = validateComputed(p)
+	if err != nil {
+		return atPath(err, "computed")
+	}
+	if p.Secret && p.Type != StringType {
+		return atPath(fmt.Errorf("secret parameter %q needs to be of type %s", p.Name, StringType), "secret")
+	}
+	return nil
+}
+
+func validateComputed(p *Parameter) error {
+	if p.Computed == "" {
+		return nil
+	}
+	if p.DefaultValue != "" || p.Enum != nil || p.Env != "" {
+		return fmt.Errorf("computed parameter %q can't define a default value, enum or environment variable", p.Name)
+	}
+	err := expression.Validate(p.Computed)
+	if err != nil {
+		return fmt.Errorf("parameter %q defines an invalid computed expression: %s", p.Name, err)
+	}
+	return nil
+}
+
+// ComputedParameters returns all parameters whose value is derived from other parameters.
+func (m *ManifestFile) ComputedParameters() []*Parameter {
+	computed := []*Parameter{}
+	for _, p := range m.Parameters {
+		if p.Computed != "" {
+			computed = append(computed, p)
+		}
+	}
+	return computed
+}
+
+// ModuleParameterName returns the name of the parameter providing the module path of a generated Go module.
+func (m *ManifestFile) ModuleParameterName() string {
+	if m.ModuleParameter == "" {
+		return defaultModuleParameter
+	}
+	return m.ModuleParameter
+}
+
+func validateGoModule(m *ManifestFile) error {
+	if !m.GoModule {
+		if m.ModuleParameter != "" {
+			return atPath(errors.New("manifest defines a module parameter but does not mark the template as Go module"), "moduleParameter")
+		}
+		return nil
+	}
+	name := m.ModuleParameterName()
+	for i, p := range m.Parameters {
+		if p.Name != name {
+			continue
+		}
+		if p.Type != ModulePathType && p.Type != StringType {
+			return atPath(fmt.Errorf("module parameter %q needs to be of type %s or %s", name, ModulePathType, StringType), "parameters", i, "type")
+		}
+		return nil
+	}
+	return atPath(fmt.Errorf("template is marked as Go module but does not define the module parameter %q", name), "goModule")
+}
+
+func validateCondition(p *Parameter) error {
+	if p.When != "" {
+		err := expression.Validate(p.When)
+		if err != nil {
+			return atPath(fmt.Errorf("parameter %q defines an invalid condition: %s", p.Name, err), "when")
+		}
+	}
+	if p.Fallback != "" {
+		if p.When == "" {
+			return atPath(fmt.Errorf("parameter %q defines a fallback without a condition", p.Name), "fallback")
+		}
+		value, err := p.ParseValue(p.Fallback)
+		if err != nil {
+			return atPath(fmt.Errorf("fallback value of parameter %q is invalid: %s", p.Name, err), "fallback")
+		}
+		err = p.Validate(value)
+		if err != nil {
+			return atPath(fmt.Errorf("fallback value of parameter %q is invalid: %s", p.Name, err), "fallback")
+		}
+	}
+	return nil
+}
+
+func validateEnum(p *Parameter) error {
+	switch p.Type {
+	case BooleanType:
+		return errors.New("boolean type does not allow enums")
+	case MapType:
+		return errors.New("map type does not allow enums")
+	}
+	for _, e := range p.Enum {
+		value, err := p.ParseValue(e)
+		if err != nil {
+			return err
+		}
+		err = p.Validate(value)
+		if err != nil {
+			return fmt.Errorf("enum value of parameter %q is invalid: %s", p.Name, err)
+		}
+	}
+	return nil
+}
+
+func isParameterType(t string) bool {
+	for _, pt := range parameterTypes {
+		if pt == t {
+			return true
+		}
+	}
+	return false
+}
+
+func validatePreGenerateHooks(hooks []*Hook) error {
+	for i, h := range hooks {
+		if (len(h.Command) == 0) == (h.Check == "") {
+			return atPath(fmt.Errorf("preGenerate hook %d needs to provide either a command or a check", i+1), "hooks", "preGenerate", i)
+		}
+		if h.WorkingDir != "" {
+			return atPath(fmt.Errorf("preGenerate hook %d can't define a working directory", i+1), "hooks", "preGenerate", i, "workingDir")
+		}
+		if h.Check != "" {
+			err := expression.Validate(h.Check)
+			if err != nil {
+				return atPath(fmt.Errorf("preGenerate hook %d defines an invalid check: %s", i+1, err), "hooks", "preGenerate", i, "check")
 			}
 		}
-		if p.Type == BooleanType {
-			if p.DefaultValue != "" {
-				_, err := strconv.ParseBool(p.DefaultValue)
-				if err != nil {
-					return err
-				}
+	}
+	return validateHooks("preGenerate", hooks)
+}
+
+func validatePostGenerateHooks(hooks []*Hook) error {
+	for i, h := range hooks {
+		if len(h.Command) == 0 {
+			return atPath(fmt.Errorf("postGenerate hook %d needs to provide a command", i+1), "hooks", "postGenerate", i)
+		}
+		if h.Check != "" {
+			return atPath(fmt.Errorf("postGenerate hook %d can't define a check", i+1), "hooks", "postGenerate", i, "check")
+		}
+	}
+	return validateHooks("postGenerate", hooks)
+}
+
+func validateHooks(stage string, hooks []*Hook) error {
+	for i, h := range hooks {
+		for j, arg := range h.Command {
+			err := expression.ValidateTemplate(arg)
+			if err != nil {
+				return atPath(fmt.Errorf("%s hook %d defines an invalid command: %s", stage, i+1, err), "hooks", stage, i, "command", j)
 			}
-			if p.Enum != nil {
-				return errors.New("boolean type does not allow enums")
+		}
+		if h.When != "" {
+			err := expression.Validate(h.When)
+			if err != nil {
+				return atPath(fmt.Errorf("%s hook %d defines an invalid condition: %s", stage, i+1, err), "hooks", stage, i, "when")
 			}
 		}
+		if filepath.IsAbs(h.WorkingDir) || strings.HasPrefix(filepath.Clean(filepath.FromSlash(h.WorkingDir)), "..") {
+			return atPath(fmt.Errorf("%s hook %d needs to define a working directory relative to the project directory", stage, i+1), "hooks", stage, i, "workingDir")
+		}
 	}
 	return nil
 }
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ManifestError describes an invalid element of a manifest.
+// The path is a JSON pointer to the element, e.g. /parameters/0/type. The line is only known for manifests loaded from YAML content.
+type ManifestError struct {
+	Path string
+	Line int
+	Err  error
+}
+
+// Error renders the path and line of the invalid element followed by the reason.
+// The path is omitted for the manifest itself.
+func (e *ManifestError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	if e.Line > 0 {
+		return fmt.Sprintf("%s (line %d): %s", e.Path, e.Line, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Err)
+}
+
+// Unwrap returns the reason the element is invalid.
+func (e *ManifestError) Unwrap() error {
+	return e.Err
+}
+
+// atPath attributes an error to the manifest element addressed by the path segments.
+// Errors already attributed to a nested element are prefixed with the path segments.
+func atPath(err error, segments ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	if me, ok := err.(*ManifestError); ok {
+		return &ManifestError{Path: pointer(segments...) + me.Path, Line: me.Line, Err: me.Err}
+	}
+	return &ManifestError{Path: pointer(segments...), Err: err}
+}
+
+// pointer builds a JSON pointer from path segments, escaping the characters ~ and / as defined by RFC 6901.
+func pointer(segments ...interface{}) string {
+	var b strings.Builder
+	for _, s := range segments {
+		b.WriteString("/")
+		switch v := s.(type) {
+		case int:
+			b.WriteString(strconv.Itoa(v))
+		default:
+			b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(fmt.Sprint(v)))
+		}
+	}
+	return b.String()
+}
+
+// splitPointer splits a JSON pointer into its unescaped segments.
+func splitPointer(p string) []string {
+	if p == "" || p == "/" {
+		return []string{}
+	}
+	segments := strings.Split(strings.TrimPrefix(p, "/"), "/")
+	for i, s := range segments {
+		segments[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(s)
+	}
+	return segments
+}
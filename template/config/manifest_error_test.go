@@ -0,0 +1,32 @@
+package config
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestManifestErrorWithLine(t *testing.T) {
+	err := &ManifestError{Path: "/parameters/0/type", Line: 5, Err: errors.New("unknown parameter type float")}
+
+	assert.Equal(t, "/parameters/0/type (line 5): unknown parameter type float", err.Error())
+}
+
+func TestAtPathPrefixesNestedErrors(t *testing.T) {
+	err := atPath(atPath(errors.New("invalid"), "type"), "parameters", 1)
+
+	assert.Equal(t, &ManifestError{Path: "/parameters/1/type", Err: errors.New("invalid")}, err)
+}
+
+func TestPointerEscapesSegments(t *testing.T) {
+	p := pointer("parameters", 0, "a/b~c")
+
+	assert.Equal(t, "/parameters/0/a~1b~0c", p)
+	assert.Equal(t, []string{"parameters", "0", "a/b~c"}, splitPointer(p))
+}
+
+func TestManifestErrorForManifest(t *testing.T) {
+	err := &ManifestError{Err: errors.New("invalid")}
+
+	assert.Equal(t, "invalid", err.Error())
+}
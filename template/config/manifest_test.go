@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -54,15 +55,15 @@ func TestValidateManifestWithEmptyVersion(t *testing.T) {
 	err := ValidateManifest(manifestFile)
 
 	assert.NotNil(t, err)
-	assert.Equal(t, "manifest file needs to provide a version", err.Error())
+	assert.Equal(t, "/version: manifest file needs to provide a version", err.Error())
 }
 
 func TestValidateManifestWithIncorrectSemVerVersion(t *testing.T) {
 	semVers := []invalidSemVer{
-		{"a.b.c", "Invalid character(s) found in major number \"a\""},
-		{"1", "No Major.Minor.Patch elements found"},
-		{"1.2", "No Major.Minor.Patch elements found"},
-		{"1.2.", "strconv.ParseUint: parsing \"\": invalid syntax"},
+		{"a.b.c", "/version: Invalid character(s) found in major number \"a\""},
+		{"1", "/version: No Major.Minor.Patch elements found"},
+		{"1.2", "/version: No Major.Minor.Patch elements found"},
+		{"1.2.", "/version: strconv.ParseUint: parsing \"\": invalid syntax"},
 	}
 	for _, sv := range semVers {
 		t.Run(sv.version, func(t *testing.T) {
@@ -80,7 +81,7 @@ func TestValidateManifestWithVersionOutOfSupportedRange(t *testing.T) {
 	err := ValidateManifest(manifestFile)
 
 	assert.NotNil(t, err)
-	assert.Equal(t, "manifest version needs to be less than 1.0.0", err.Error())
+	assert.Equal(t, "/version: manifest version needs to be less than 2.0.0", err.Error())
 }
 
 func TestValidateManifestWithMaxSupportedVersion(t *testing.T) {
@@ -107,7 +108,7 @@ func TestValidateManifestWithEmptyParameterType(t *testing.T) {
 	err := ValidateManifest(manifestFile)
 
 	assert.NotNil(t, err)
-	assert.Equal(t, "every parameter defined in manifest needs to provide a type", err.Error())
+	assert.Equal(t, "/parameters/0/type: every parameter defined in manifest needs to provide a type", err.Error())
 }
 
 func TestValidateManifestWithIncorrectIntegerParameterDefaultValue(t *testing.T) {
@@ -120,7 +121,7 @@ func TestValidateManifestWithIncorrectIntegerParameterDefaultValue(t *testing.T)
 	err := ValidateManifest(manifestFile)
 
 	assert.NotNil(t, err)
-	assert.Equal(t, "strconv.Atoi: parsing \"abc\": invalid syntax", err.Error())
+	assert.Equal(t, "/parameters/0/defaultValue: strconv.Atoi: parsing \"abc\": invalid syntax", err.Error())
 }
 
 func TestValidateManifestWithIncorrectIntegerEnumValues(t *testing.T) {
@@ -133,7 +134,7 @@ func TestValidateManifestWithIncorrectIntegerEnumValues(t *testing.T) {
 	err := ValidateManifest(manifestFile)
 
 	assert.NotNil(t, err)
-	assert.Equal(t, "strconv.Atoi: parsing \"abc\": invalid syntax", err.Error())
+	assert.Equal(t, "/parameters/0/enum: strconv.Atoi: parsing \"abc\": invalid syntax", err.Error())
 }
 
 func TestValidateManifestWithIncorrectBooleanParameterDefaultValue(t *testing.T) {
@@ -146,7 +147,7 @@ func TestValidateManifestWithIncorrectBooleanParameterDefaultValue(t *testing.T)
 	err := ValidateManifest(manifestFile)
 
 	assert.NotNil(t, err)
-	assert.Equal(t, "strconv.ParseBool: parsing \"notaboolean\": invalid syntax", err.Error())
+	assert.Equal(t, "/parameters/0/defaultValue: strconv.ParseBool: parsing \"notaboolean\": invalid syntax", err.Error())
 }
 
 func TestValidateManifestWithBooleanEnumValues(t *testing.T) {
@@ -159,10 +160,335 @@ func TestValidateManifestWithBooleanEnumValues(t *testing.T) {
 	err := ValidateManifest(manifestFile)
 
 	assert.NotNil(t, err)
-	assert.Equal(t, "boolean type does not allow enums", err.Error())
+	assert.Equal(t, "/parameters/0/enum: boolean type does not allow enums", err.Error())
 }
 
 type invalidSemVer struct {
 	version      string
 	errorMessage string
 }
+
+func TestValidateManifestWithUnknownParameterType(t *testing.T) {
+	manifestFile := &ManifestFile{
+		Version: "1.0.0",
+		Parameters: []*Parameter{
+			{Type: "float"},
+		},
+	}
+	err := ValidateManifest(manifestFile)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "/parameters/0/type: unknown parameter type float", err.Error())
+}
+
+func TestValidateManifestReportsLineOfInvalidElement(t *testing.T) {
+	manifestFile, err := LoadManifestData([]byte(`version: "1.0.0"
+parameters:
+  - name: "module"
+    type: "string"
+  - name: "ratio"
+    type: "float"`))
+	assert.Nil(t, err)
+	err = ValidateManifest(manifestFile)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, &ManifestError{Path: "/parameters/1/type", Line: 6, Err: errors.New("unknown parameter type float")}, err)
+	assert.Equal(t, "/parameters/1/type (line 6): unknown parameter type float", err.Error())
+}
+
+func TestValidateManifestWithMapEnumValues(t *testing.T) {
+	manifestFile := &ManifestFile{
+		Version: "1.0.0",
+		Parameters: []*Parameter{
+			{Type: "map", Enum: []string{"a=b"}},
+		},
+	}
+	err := ValidateManifest(manifestFile)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "/parameters/0/enum: map type does not allow enums", err.Error())
+}
+
+func TestValidateManifestWithIncorrectModulePathParameterDefaultValue(t *testing.T) {
+	manifestFile := &ManifestFile{
+		Version: "1.0.0",
+		Parameters: []*Parameter{
+			{Type: "modulePath", DefaultValue: "github.com/"},
+		},
+	}
+	err := ValidateManifest(manifestFile)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "/parameters/0/defaultValue: malformed module path \"github.com/\": empty path element", err.Error())
+}
+
+func TestValidateManifestWithValidExtendedParameterTypes(t *testing.T) {
+	manifestFile := &ManifestFile{
+		Version: "1.0.0",
+		Parameters: []*Parameter{
+			{Type: "list", Enum: []string{"api", "worker"}, DefaultValue: "api"},
+			{Type: "map", DefaultValue: "team=core"},
+			{Type: "path", DefaultValue: "cmd/server"},
+			{Type: "url", DefaultValue: "https://example.com"},
+			{Type: "email", DefaultValue: "gopher@example.com"},
+			{Type: "semver", Enum: []string{"1.20", "1.21"}},
+			{Type: "modulePath", DefaultValue: "github.com/example/service"},
+		},
+	}
+	err := ValidateManifest(manifestFile)
+
+	assert.Nil(t, err)
+}
+
+func TestValidateManifestWithInconsistentValidationRules(t *testing.T) {
+	params := []struct {
+		name         string
+		param        *Parameter
+		errorMessage string
+	}{
+		{"invalid pattern", &Parameter{Name: "p", Type: "string", Pattern: "[a-z"}, "/parameters/0: parameter \"p\" defines an invalid pattern: error parsing regexp: missing closing ]: `[a-z`"},
+		{"pattern for integer", &Parameter{Name: "p", Type: "integer", Pattern: "^1"}, "/parameters/0: parameter \"p\" of type integer does not support pattern or length rules"},
+		{"min for string", &Parameter{Name: "p", Type: "string", Min: intPtr(1)}, "/parameters/0: parameter \"p\" of type string does not support min or max rules"},
+		{"negative length", &Parameter{Name: "p", Type: "string", MinLength: intPtr(-1)}, "/parameters/0: parameter \"p\" needs to define non-negative length rules"},
+		{"length range", &Parameter{Name: "p", Type: "string", MinLength: intPtr(5), MaxLength: intPtr(2)}, "/parameters/0: parameter \"p\" defines a minLength greater than its maxLength"},
+		{"integer range", &Parameter{Name: "p", Type: "integer", Min: intPtr(5), Max: intPtr(2)}, "/parameters/0: parameter \"p\" defines a min greater than its max"},
+		{"default outside range", &Parameter{Name: "p", Type: "integer", Max: intPtr(2), DefaultValue: "3"}, "/parameters/0/defaultValue: default value of parameter \"p\" is invalid: value 3 needs to be less than or equal to 2"},
+		{"default not matching", &Parameter{Name: "p", Type: "string", Pattern: "^[a-z]+$", DefaultValue: "A"}, "/parameters/0/defaultValue: default value of parameter \"p\" is invalid: value \"A\" does not match pattern \"^[a-z]+$\""},
+		{"enum not matching", &Parameter{Name: "p", Type: "string", MaxLength: intPtr(1), Enum: []string{"a", "bb"}}, "/parameters/0/enum: enum value of parameter \"p\" is invalid: value \"bb\" needs to be at most 1 characters long"},
+	}
+	for _, p := range params {
+		t.Run(p.name, func(t *testing.T) {
+			manifestFile := &ManifestFile{
+				Version:    "1.0.0",
+				Parameters: []*Parameter{p.param},
+			}
+			err := ValidateManifest(manifestFile)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, p.errorMessage, err.Error())
+		})
+	}
+}
+
+func TestValidateManifestWithConditionalParameters(t *testing.T) {
+	params := []struct {
+		name         string
+		param        *Parameter
+		errorMessage string
+	}{
+		{"fallback without condition", &Parameter{Name: "p", Type: "string", Fallback: "none"}, "/parameters/0/fallback: parameter \"p\" defines a fallback without a condition"},
+		{"invalid fallback", &Parameter{Name: "p", Type: "integer", When: ".a", Fallback: "none"}, "/parameters/0/fallback: fallback value of parameter \"p\" is invalid: strconv.Atoi: parsing \"none\": invalid syntax"},
+	}
+	for _, p := range params {
+		t.Run(p.name, func(t *testing.T) {
+			manifestFile := &ManifestFile{
+				Version:    "1.0.0",
+				Parameters: []*Parameter{p.param},
+			}
+			err := ValidateManifest(manifestFile)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, p.errorMessage, err.Error())
+		})
+	}
+}
+
+func TestValidateManifestWithInvalidCondition(t *testing.T) {
+	manifestFile := &ManifestFile{
+		Version: "1.0.0",
+		Parameters: []*Parameter{
+			{Name: "p", Type: "string", When: "eq .a )"},
+		},
+	}
+	err := ValidateManifest(manifestFile)
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "parameter \"p\" defines an invalid condition: template: expression:1:")
+}
+
+func TestValidateManifestWithComputedParameters(t *testing.T) {
+	params := []struct {
+		name         string
+		param        *Parameter
+		errorMessage string
+	}{
+		{"default value", &Parameter{Name: "p", Type: "string", Computed: ".a", DefaultValue: "b"}, "/parameters/0/computed: computed parameter \"p\" can't define a default value, enum or environment variable"},
+		{"enum", &Parameter{Name: "p", Type: "string", Computed: ".a", Enum: []string{"b"}}, "/parameters/0/computed: computed parameter \"p\" can't define a default value, enum or environment variable"},
+		{"unknown function", &Parameter{Name: "p", Type: "string", Computed: "unknown .a"}, "/parameters/0/computed: parameter \"p\" defines an invalid computed expression: template: expression:1: function \"unknown\" not defined"},
+	}
+	for _, p := range params {
+		t.Run(p.name, func(t *testing.T) {
+			manifestFile := &ManifestFile{
+				Version:    "1.0.0",
+				Parameters: []*Parameter{p.param},
+			}
+			err := ValidateManifest(manifestFile)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, p.errorMessage, err.Error())
+		})
+	}
+}
+
+func TestComputedParameters(t *testing.T) {
+	name := &Parameter{Name: "name", Type: "string"}
+	pkg := &Parameter{Name: "package", Type: "string", Computed: "packageName .name"}
+	manifestFile := &ManifestFile{
+		Version:    "1.0.0",
+		Parameters: []*Parameter{name, pkg},
+	}
+
+	assert.Equal(t, []*Parameter{pkg}, manifestFile.ComputedParameters())
+}
+
+func TestValidateManifestWithTemplatedDefaultValues(t *testing.T) {
+	manifestFile := &ManifestFile{
+		Version: "1.0.0",
+		Parameters: []*Parameter{
+			{Name: "module", Type: "modulePath", DefaultValue: "github.com/{{ .org }}/{{ .name }}"},
+			{Name: "port", Type: "integer", DefaultValue: "{{ .basePort }}"},
+		},
+	}
+	err := ValidateManifest(manifestFile)
+
+	assert.Nil(t, err)
+}
+
+func TestValidateManifestWithInvalidDefaultValueTemplate(t *testing.T) {
+	manifestFile := &ManifestFile{
+		Version: "1.0.0",
+		Parameters: []*Parameter{
+			{Name: "module", Type: "string", DefaultValue: "{{ .org "},
+		},
+	}
+	err := ValidateManifest(manifestFile)
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "parameter \"module\" defines an invalid default value template:")
+}
+
+func TestValidateManifestWithInvalidHooks(t *testing.T) {
+	hooks := []struct {
+		name         string
+		hook         *Hook
+		errorMessage string
+	}{
+		{"missing command", &Hook{}, "/hooks/postGenerate/0: postGenerate hook 1 needs to provide a command"},
+		{"absolute working dir", &Hook{Command: []string{"ls"}, WorkingDir: "/tmp"}, "/hooks/postGenerate/0/workingDir: postGenerate hook 1 needs to define a working directory relative to the project directory"},
+		{"parent working dir", &Hook{Command: []string{"ls"}, WorkingDir: "../other"}, "/hooks/postGenerate/0/workingDir: postGenerate hook 1 needs to define a working directory relative to the project directory"},
+	}
+	for _, h := range hooks {
+		t.Run(h.name, func(t *testing.T) {
+			manifestFile := &ManifestFile{
+				Version: "1.0.0",
+				Hooks:   Hooks{PostGenerate: []*Hook{h.hook}},
+			}
+			err := ValidateManifest(manifestFile)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, h.errorMessage, err.Error())
+		})
+	}
+}
+
+func TestLoadManifestDataWithHooks(t *testing.T) {
+	content := []byte(`version: "0.1.0"
+hooks:
+  postGenerate:
+    - command: ["go", "mod", "tidy"]
+    - name: "git"
+      command: ["git", "init"]
+      when: ".useGit"
+      workingDir: "src"`)
+	manifestFile, err := LoadManifestData(content)
+
+	assert.Nil(t, err)
+	assert.Nil(t, ValidateManifest(manifestFile))
+	assert.Equal(t, []*Hook{
+		{Command: []string{"go", "mod", "tidy"}},
+		{Name: "git", Command: []string{"git", "init"}, When: ".useGit", WorkingDir: "src"},
+	}, manifestFile.Hooks.PostGenerate)
+}
+
+func TestValidateManifestWithInvalidPreGenerateHooks(t *testing.T) {
+	hooks := []struct {
+		name         string
+		hook         *Hook
+		errorMessage string
+	}{
+		{"missing command and check", &Hook{}, "/hooks/preGenerate/0: preGenerate hook 1 needs to provide either a command or a check"},
+		{"command and check", &Hook{Command: []string{"ls"}, Check: ".a"}, "/hooks/preGenerate/0: preGenerate hook 1 needs to provide either a command or a check"},
+		{"working dir", &Hook{Command: []string{"ls"}, WorkingDir: "src"}, "/hooks/preGenerate/0/workingDir: preGenerate hook 1 can't define a working directory"},
+	}
+	for _, h := range hooks {
+		t.Run(h.name, func(t *testing.T) {
+			manifestFile := &ManifestFile{
+				Version: "1.0.0",
+				Hooks:   Hooks{PreGenerate: []*Hook{h.hook}},
+			}
+			err := ValidateManifest(manifestFile)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, h.errorMessage, err.Error())
+		})
+	}
+}
+
+func TestValidateManifestWithCheckInPostGenerateHook(t *testing.T) {
+	manifestFile := &ManifestFile{
+		Version: "1.0.0",
+		Hooks:   Hooks{PostGenerate: []*Hook{{Command: []string{"ls"}, Check: ".a"}}},
+	}
+	err := ValidateManifest(manifestFile)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "/hooks/postGenerate/0/check: postGenerate hook 1 can't define a check", err.Error())
+}
+
+func TestValidateManifestForGoModule(t *testing.T) {
+	manifests := []struct {
+		name         string
+		manifest     *ManifestFile
+		errorMessage string
+	}{
+		{"default parameter", &ManifestFile{Version: "1.0.0", GoModule: true, Parameters: []*Parameter{{Name: "module", Type: ModulePathType}}}, ""},
+		{"custom parameter", &ManifestFile{Version: "1.0.0", GoModule: true, ModuleParameter: "path", Parameters: []*Parameter{{Name: "path", Type: StringType}}}, ""},
+		{"missing parameter", &ManifestFile{Version: "1.0.0", GoModule: true}, "/goModule: template is marked as Go module but does not define the module parameter \"module\""},
+		{"wrong parameter type", &ManifestFile{Version: "1.0.0", GoModule: true, Parameters: []*Parameter{{Name: "module", Type: IntegerType}}}, "/parameters/0/type: module parameter \"module\" needs to be of type modulePath or string"},
+		{"parameter without module", &ManifestFile{Version: "1.0.0", ModuleParameter: "path"}, "/moduleParameter: manifest defines a module parameter but does not mark the template as Go module"},
+	}
+	for _, m := range manifests {
+		t.Run(m.name, func(t *testing.T) {
+			err := ValidateManifest(m.manifest)
+
+			if m.errorMessage == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.NotNil(t, err)
+				assert.Equal(t, m.errorMessage, err.Error())
+			}
+		})
+	}
+}
+
+func TestValidateManifestWithSecretParameterOfWrongType(t *testing.T) {
+	manifestFile := &ManifestFile{
+		Version:    "1.0.0",
+		Parameters: []*Parameter{{Name: "port", Type: IntegerType, Secret: true}},
+	}
+	err := ValidateManifest(manifestFile)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "/parameters/0/secret: secret parameter \"port\" needs to be of type string", err.Error())
+}
+
+func TestManifestLine(t *testing.T) {
+	manifestFile, err := LoadManifestData([]byte(`version: "1.0.0"
+parameters:
+  - name: "module"
+    type: "string"`))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, manifestFile.Line("/parameters/0"))
+	assert.Equal(t, 0, (&ManifestFile{}).Line("/parameters/0"))
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/blang/semver"
+	"github.com/bmuschko/letsgopher/template/version"
+	"strings"
+)
+
+// metadataManifestVersion is the first manifest version describing a template with metadata.
+const metadataManifestVersion = "2.0.0"
+
+// Metadata describes a template for users browsing and selecting templates.
+// It can only be declared by manifests of version 2.0.0 or higher.
+type Metadata struct {
+	Name                 string   `json:"name,omitempty"`
+	Description          string   `json:"description,omitempty"`
+	Authors              []string `json:"authors,omitempty"`
+	License              string   `json:"license,omitempty"`
+	Homepage             string   `json:"homepage,omitempty"`
+	Tags                 []string `json:"tags,omitempty"`
+	Keywords             []string `json:"keywords,omitempty"`
+	MinLetsgopherVersion string   `json:"minLetsgopherVersion,omitempty"`
+	MaxLetsgopherVersion string   `json:"maxLetsgopherVersion,omitempty"`
+	Icon                 string   `json:"icon,omitempty"`
+}
+
+// IsEmpty determines whether none of the metadata has been declared.
+func (m *Metadata) IsEmpty() bool {
+	return m.Name == "" && m.Description == "" && len(m.Authors) == 0 && m.License == "" && m.Homepage == "" &&
+		len(m.Tags) == 0 && len(m.Keywords) == 0 && m.MinLetsgopherVersion == "" && m.MaxLetsgopherVersion == "" && m.Icon == ""
+}
+
+func validateMetadata(manifestVersion string, m *Metadata) error {
+	if m.IsEmpty() {
+		return nil
+	}
+	v, err := semver.Make(manifestVersion)
+	if err != nil {
+		return atPath(err, "version")
+	}
+	if v.LT(semver.MustParse(metadataManifestVersion)) {
+		return atPath(fmt.Errorf("manifest declares template metadata which requires manifest version %s or higher", metadataManifestVersion), "version")
+	}
+	for i, a := range m.Authors {
+		if strings.TrimSpace(a) == "" {
+			return atPath(errors.New("manifest needs to provide the names of all authors"), "authors", i)
+		}
+	}
+	if m.Homepage != "" {
+		_, err := parseURL(m.Homepage)
+		if err != nil {
+			return atPath(fmt.Errorf("manifest declares an invalid homepage: %s", err), "homepage")
+		}
+	}
+	min, err := parseLetsgopherVersion("minimum", m.MinLetsgopherVersion)
+	if err != nil {
+		return atPath(err, "minLetsgopherVersion")
+	}
+	max, err := parseLetsgopherVersion("maximum", m.MaxLetsgopherVersion)
+	if err != nil {
+		return atPath(err, "maxLetsgopherVersion")
+	}
+	if min != nil && max != nil && min.GT(*max) {
+		return atPath(fmt.Errorf("manifest declares a minimum letsgopher version %s greater than the maximum letsgopher version %s", m.MinLetsgopherVersion, m.MaxLetsgopherVersion), "minLetsgopherVersion")
+	}
+	return nil
+}
+
+func parseLetsgopherVersion(bound string, v string) (*semver.Version, error) {
+	if v == "" {
+		return nil, nil
+	}
+	parsed, err := version.Parse(v)
+	if err != nil {
+		return nil, fmt.Errorf("manifest declares an invalid %s letsgopher version %q: %s", bound, v, err)
+	}
+	return &parsed, nil
+}
+
+// CheckLetsgopherVersion verifies that the template supports the running letsgopher version.
+// Versions which are not semantic versions, e.g. of development builds, are not checked.
+// Invalid version bounds are left to manifest validation.
+func (m *Metadata) CheckLetsgopherVersion(current string) error {
+	v, err := version.Parse(current)
+	if err != nil {
+		return nil
+	}
+	if min, err := version.Parse(m.MinLetsgopherVersion); err == nil && v.LT(min) {
+		return fmt.Errorf("letsgopher %s or higher is required but this is letsgopher %s, please upgrade letsgopher", m.MinLetsgopherVersion, current)
+	}
+	if max, err := version.Parse(m.MaxLetsgopherVersion); err == nil && v.GT(max) {
+		return fmt.Errorf("letsgopher %s is supported at most but this is letsgopher %s, please use a different version of the template", m.MaxLetsgopherVersion, current)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLoadManifestDataWithMetadata(t *testing.T) {
+	manifestFile, err := LoadManifestData([]byte(`version: "2.0.0"
+name: "go-service"
+description: "A Go service exposing a REST API"
+authors: ["Jane Doe <jane@example.com>"]
+license: "Apache-2.0"
+homepage: "https://github.com/acme/go-service"
+tags: ["service", "rest"]
+keywords: ["http", "api"]
+minLetsgopherVersion: "0.4.0"
+icon: "https://github.com/acme/go-service/icon.png"
+parameters:
+  - name: "module"
+    type: "string"`))
+
+	assert.Nil(t, err)
+	assert.Equal(t, Metadata{
+		Name:                 "go-service",
+		Description:          "A Go service exposing a REST API",
+		Authors:              []string{"Jane Doe <jane@example.com>"},
+		License:              "Apache-2.0",
+		Homepage:             "https://github.com/acme/go-service",
+		Tags:                 []string{"service", "rest"},
+		Keywords:             []string{"http", "api"},
+		MinLetsgopherVersion: "0.4.0",
+		Icon:                 "https://github.com/acme/go-service/icon.png",
+	}, manifestFile.Metadata)
+	assert.Equal(t, 1, len(manifestFile.Parameters))
+	assert.Nil(t, ValidateManifest(manifestFile))
+}
+
+func TestValidateManifestWithoutMetadataForVersion1(t *testing.T) {
+	manifestFile, err := LoadManifestData([]byte(`version: "1.0.0"
+parameters:
+  - name: "module"
+    type: "string"`))
+
+	assert.Nil(t, err)
+	assert.True(t, manifestFile.Metadata.IsEmpty())
+	assert.Nil(t, ValidateManifest(manifestFile))
+}
+
+func TestValidateManifestWithInvalidMetadata(t *testing.T) {
+	manifests := []struct {
+		manifest *ManifestFile
+		expected string
+	}{
+		{&ManifestFile{Version: "1.0.0", Metadata: Metadata{Description: "A Go service"}}, "manifest declares template metadata which requires manifest version 2.0.0 or higher"},
+		{&ManifestFile{Version: "2.0.0", Metadata: Metadata{Authors: []string{" "}}}, "manifest needs to provide the names of all authors"},
+		{&ManifestFile{Version: "2.0.0", Metadata: Metadata{Homepage: "github.com/acme"}}, "manifest declares an invalid homepage: URL \"github.com/acme\" needs to provide a scheme and a host"},
+		{&ManifestFile{Version: "2.0.0", Metadata: Metadata{MinLetsgopherVersion: "latest"}}, "manifest declares an invalid minimum letsgopher version \"latest\""},
+	}
+
+	for _, m := range manifests {
+		t.Run(m.expected, func(t *testing.T) {
+			err := ValidateManifest(m.manifest)
+
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), m.expected)
+		})
+	}
+}
+
+func TestValidateManifestWithMinimumVersionGreaterThanMaximumVersion(t *testing.T) {
+	manifestFile := &ManifestFile{Version: "2.0.0", Metadata: Metadata{MinLetsgopherVersion: "0.5.0", MaxLetsgopherVersion: "0.4"}}
+	err := ValidateManifest(manifestFile)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "/minLetsgopherVersion: manifest declares a minimum letsgopher version 0.5.0 greater than the maximum letsgopher version 0.4", err.Error())
+}
+
+func TestCheckLetsgopherVersion(t *testing.T) {
+	versions := []struct {
+		current  string
+		expected string
+	}{
+		{"0.4.9", "letsgopher 0.5.0 or higher is required but this is letsgopher 0.4.9, please upgrade letsgopher"},
+		{"0.5.0", ""},
+		{"v0.7.0", ""},
+		{"0.7.1", "letsgopher 0.7 is supported at most but this is letsgopher 0.7.1, please use a different version of the template"},
+		{"undefined", ""},
+		{"", ""},
+	}
+
+	for _, v := range versions {
+		t.Run(v.current, func(t *testing.T) {
+			m := &Metadata{MinLetsgopherVersion: "0.5.0", MaxLetsgopherVersion: "0.7"}
+			err := m.CheckLetsgopherVersion(v.current)
+
+			if v.expected == "" {
+				assert.Nil(t, err)
+				return
+			}
+			assert.NotNil(t, err)
+			assert.Equal(t, v.expected, err.Error())
+		})
+	}
+}
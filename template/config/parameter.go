@@ -0,0 +1,186 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/blang/semver"
+	"github.com/bmuschko/letsgopher/template/expression"
+	"net/mail"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	listSeparator     = ","
+	keyValueSeparator = "="
+)
+
+// ParseValue converts the textual representation of a value into the Go type matching the parameter type.
+// Lists are represented as comma-separated values, maps as comma-separated key/value pairs separated by =.
+func (p *Parameter) ParseValue(s string) (interface{}, error) {
+	switch p.Type {
+	case StringType:
+		return s, nil
+	case IntegerType:
+		return strconv.Atoi(s)
+	case BooleanType:
+		return strconv.ParseBool(s)
+	case ListType:
+		return parseList(s), nil
+	case MapType:
+		return parseMap(s)
+	case PathType:
+		return parsePath(s)
+	case URLType:
+		return parseURL(s)
+	case EmailType:
+		return parseEmail(s)
+	case SemVerType:
+		return parseSemVer(s)
+	case ModulePathType:
+		return s, CheckModulePath(s)
+	}
+	return nil, fmt.Errorf("unknown parameter type %s", p.Type)
+}
+
+// RenderDefaultValue renders the default value of the parameter against the values resolved so far.
+// Default values may use template actions, e.g. "github.com/{{ .org }}/{{ .name }}" or "{{ gitUserName }}".
+func (p *Parameter) RenderDefaultValue(replacements map[string]interface{}) (string, error) {
+	if !expression.IsTemplate(p.DefaultValue) {
+		return p.DefaultValue, nil
+	}
+	s, err := expression.Render(p.DefaultValue, replacements)
+	if err != nil {
+		return "", fmt.Errorf("failed to render default value of parameter %q: %s", p.Name, err)
+	}
+	return s, nil
+}
+
+// FormatValue converts a typed value into its textual representation understood by ParseValue.
+func FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case []string:
+		return strings.Join(v, listSeparator)
+	case []interface{}:
+		items := []string{}
+		for _, i := range v {
+			items = append(items, FormatValue(i))
+		}
+		return strings.Join(items, listSeparator)
+	case map[string]string:
+		pairs := []string{}
+		for k, i := range v {
+			pairs = append(pairs, k+keyValueSeparator+i)
+		}
+		sort.Strings(pairs)
+		return strings.Join(pairs, listSeparator)
+	case map[string]interface{}:
+		pairs := []string{}
+		for k, i := range v {
+			pairs = append(pairs, k+keyValueSeparator+FormatValue(i))
+		}
+		sort.Strings(pairs)
+		return strings.Join(pairs, listSeparator)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+func parseList(s string) []string {
+	items := []string{}
+	for _, i := range strings.Split(s, listSeparator) {
+		i = strings.TrimSpace(i)
+		if i != "" {
+			items = append(items, i)
+		}
+	}
+	return items
+}
+
+func parseMap(s string) (map[string]string, error) {
+	m := make(map[string]string)
+	for _, pair := range parseList(s) {
+		kv := strings.SplitN(pair, keyValueSeparator, 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("map entry %q does not separate key and value by %s character", pair, keyValueSeparator)
+		}
+		m[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return m, nil
+}
+
+func parsePath(s string) (string, error) {
+	if s == "" {
+		return "", errors.New("path must not be empty")
+	}
+	if strings.ContainsRune(s, 0) {
+		return "", fmt.Errorf("path %q must not contain NUL characters", s)
+	}
+	return filepath.Clean(s), nil
+}
+
+func parseURL(s string) (string, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("URL %q needs to provide a scheme and a host", s)
+	}
+	return s, nil
+}
+
+func parseEmail(s string) (string, error) {
+	a, err := mail.ParseAddress(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid email address %q: %s", s, err)
+	}
+	if a.Address != s {
+		return "", fmt.Errorf("invalid email address %q: expected a plain address without name", s)
+	}
+	return s, nil
+}
+
+func parseSemVer(s string) (string, error) {
+	_, err := semver.ParseTolerant(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid semantic version %q: %s", s, err)
+	}
+	return s, nil
+}
+
+// CheckModulePath verifies that a string is a valid Go module path.
+// It follows the rules for import paths: slash-separated elements made of ASCII letters, digits and the characters -._~,
+// where no element is empty, starts or ends with a dot, and the first element is a lower-case domain-like name if it contains a dot.
+func CheckModulePath(path string) error {
+	if path == "" {
+		return errors.New("module path must not be empty")
+	}
+	elems := strings.Split(path, "/")
+	for i, elem := range elems {
+		if elem == "" {
+			return fmt.Errorf("malformed module path %q: empty path element", path)
+		}
+		if elem[0] == '.' || elem[len(elem)-1] == '.' {
+			return fmt.Errorf("malformed module path %q: leading or trailing dot in path element", path)
+		}
+		for _, r := range elem {
+			if !isModulePathChar(r) {
+				return fmt.Errorf("malformed module path %q: invalid char %q", path, r)
+			}
+		}
+		if i == 0 && strings.Contains(elem, ".") && strings.ToLower(elem) != elem {
+			return fmt.Errorf("malformed module path %q: domain name needs to be lower-case", path)
+		}
+	}
+	return nil
+}
+
+func isModulePathChar(r rune) bool {
+	return 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' ||
+		r == '-' || r == '.' || r == '_' || r == '~'
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseValueForValidValues(t *testing.T) {
+	values := []struct {
+		paramType string
+		input     string
+		expected  interface{}
+	}{
+		{StringType, "hello", "hello"},
+		{IntegerType, "42", 42},
+		{BooleanType, "true", true},
+		{ListType, "api, worker,,cron", []string{"api", "worker", "cron"}},
+		{ListType, "", []string{}},
+		{MapType, "team=core, tier=backend", map[string]string{"team": "core", "tier": "backend"}},
+		{MapType, "query=a=b", map[string]string{"query": "a=b"}},
+		{PathType, "cmd//server/", "cmd/server"},
+		{URLType, "https://github.com/bmuschko", "https://github.com/bmuschko"},
+		{EmailType, "gopher@example.com", "gopher@example.com"},
+		{SemVerType, "1.21", "1.21"},
+		{SemVerType, "v1.2.3", "v1.2.3"},
+		{ModulePathType, "github.com/bmuschko/letsgopher", "github.com/bmuschko/letsgopher"},
+		{ModulePathType, "hello-world", "hello-world"},
+	}
+	for _, v := range values {
+		t.Run(v.paramType+"/"+v.input, func(t *testing.T) {
+			p := &Parameter{Type: v.paramType}
+			value, err := p.ParseValue(v.input)
+
+			assert.Nil(t, err)
+			assert.Equal(t, v.expected, value)
+		})
+	}
+}
+
+func TestParseValueForInvalidValues(t *testing.T) {
+	values := []struct {
+		paramType    string
+		input        string
+		errorMessage string
+	}{
+		{IntegerType, "abc", "strconv.Atoi: parsing \"abc\": invalid syntax"},
+		{MapType, "team", "map entry \"team\" does not separate key and value by = character"},
+		{PathType, "", "path must not be empty"},
+		{URLType, "github.com/bmuschko", "URL \"github.com/bmuschko\" needs to provide a scheme and a host"},
+		{EmailType, "Gopher <gopher@example.com>", "invalid email address \"Gopher <gopher@example.com>\": expected a plain address without name"},
+		{SemVerType, "latest", "invalid semantic version \"latest\": Invalid character(s) found in major number \"latest\""},
+		{ModulePathType, "github.com//letsgopher", "malformed module path \"github.com//letsgopher\": empty path element"},
+		{ModulePathType, "GitHub.com/bmuschko", "malformed module path \"GitHub.com/bmuschko\": domain name needs to be lower-case"},
+		{ModulePathType, "github.com/bmuschko/lets gopher", "malformed module path \"github.com/bmuschko/lets gopher\": invalid char ' '"},
+		{ModulePathType, "github.com/.hidden", "malformed module path \"github.com/.hidden\": leading or trailing dot in path element"},
+		{"unknown", "value", "unknown parameter type unknown"},
+	}
+	for _, v := range values {
+		t.Run(v.paramType+"/"+v.input, func(t *testing.T) {
+			p := &Parameter{Type: v.paramType}
+			_, err := p.ParseValue(v.input)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, v.errorMessage, err.Error())
+		})
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	assert.Equal(t, "hello", FormatValue("hello"))
+	assert.Equal(t, "42", FormatValue(42))
+	assert.Equal(t, "a,b", FormatValue([]string{"a", "b"}))
+	assert.Equal(t, "a,1", FormatValue([]interface{}{"a", 1}))
+	assert.Equal(t, "a=1,b=2", FormatValue(map[string]string{"b": "2", "a": "1"}))
+	assert.Equal(t, "a=1,b=x", FormatValue(map[string]interface{}{"b": "x", "a": 1}))
+	assert.Equal(t, "1000000", FormatValue(float64(1000000)))
+}
+
+func TestRenderDefaultValue(t *testing.T) {
+	replacements := map[string]interface{}{"org": "acme", "name": "order-service"}
+	defaults := []struct {
+		defaultValue string
+		expected     string
+	}{
+		{"", ""},
+		{"Hello World!", "Hello World!"},
+		{"github.com/{{ .org }}/{{ .name }}", "github.com/acme/order-service"},
+		{"{{ .name | packageName }}", "orderservice"},
+		{"{{ .unknown }}", ""},
+	}
+	for _, d := range defaults {
+		t.Run(d.defaultValue, func(t *testing.T) {
+			p := &Parameter{Name: "p", Type: StringType, DefaultValue: d.defaultValue}
+			s, err := p.RenderDefaultValue(replacements)
+
+			assert.Nil(t, err)
+			assert.Equal(t, d.expected, s)
+		})
+	}
+}
+
+func TestRenderDefaultValueWithFailingTemplate(t *testing.T) {
+	p := &Parameter{Name: "p", Type: StringType, DefaultValue: "{{ index .name 5 }}"}
+	_, err := p.RenderDefaultValue(map[string]interface{}{"name": []string{}})
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "failed to render default value of parameter \"p\"")
+}
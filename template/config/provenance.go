@@ -0,0 +1,110 @@
+package config
+
+import (
+	"github.com/ghodss/yaml"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+// ProvenanceFileName is the name of the file recording how a project has been generated.
+const ProvenanceFileName = ".letsgopher.yaml"
+
+// ProvenanceFile records the template and parameter values a project has been generated from.
+// Format indicates that the generated Go source files have been formatted on request of the user.
+type ProvenanceFile struct {
+	Generated    time.Time              `json:"generated"`
+	Template     TemplateReference      `json:"template"`
+	Parameters   map[string]interface{} `json:"parameters"`
+	Format       bool                   `json:"format,omitempty"`
+	Dependencies []*DependencyReference `json:"dependencies,omitempty"`
+}
+
+// TemplateReference identifies the template archive a project has been generated from.
+type TemplateReference struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Digest    string `json:"digest"`
+	SourceURL string `json:"sourceURL,omitempty"`
+}
+
+// DependencyReference identifies the template archive a dependency has been generated from.
+// The directory is relative to the project directory.
+type DependencyReference struct {
+	TemplateReference
+	Directory  string                 `json:"directory,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// NewDependencyReference records the template and the parameter values of a generated dependency.
+// Values of secret parameters are not recorded.
+func NewDependencyReference(template TemplateReference, directory string, params []*Parameter, replacements map[string]interface{}) *DependencyReference {
+	return &DependencyReference{
+		TemplateReference: template,
+		Directory:         directory,
+		Parameters:        withoutSecrets(params, replacements),
+	}
+}
+
+// NewProvenanceFile creates a provenance file for a template and the resolved parameter values.
+// Values of secret parameters are not recorded.
+func NewProvenanceFile(template TemplateReference, params []*Parameter, replacements map[string]interface{}) *ProvenanceFile {
+	return &ProvenanceFile{
+		Generated:  time.Now(),
+		Template:   template,
+		Parameters: withoutSecrets(params, replacements),
+	}
+}
+
+func withoutSecrets(params []*Parameter, replacements map[string]interface{}) map[string]interface{} {
+	secrets := make(map[string]bool)
+	for _, p := range params {
+		if p.Secret {
+			secrets[p.Name] = true
+		}
+	}
+	values := make(map[string]interface{})
+	for k, v := range replacements {
+		if !secrets[k] {
+			values[k] = v
+		}
+	}
+	return values
+}
+
+// LoadProvenanceFile loads the provenance file of a generated project.
+func LoadProvenanceFile(path string) (*ProvenanceFile, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &ProvenanceFile{}
+	err = yaml.Unmarshal(b, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Answers returns the recorded parameter values in the textual representation understood by Parameter.ParseValue.
+func (p *ProvenanceFile) Answers() map[string]string {
+	answers := make(map[string]string)
+	for k, v := range p.Parameters {
+		if v == nil {
+			continue
+		}
+		answers[k] = FormatValue(v)
+	}
+	return answers
+}
+
+// WriteFile writes the provenance file.
+func (p *ProvenanceFile) WriteFile(path string, perm os.FileMode) error {
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, perm)
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"github.com/Flaque/filet"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewProvenanceFileExcludesSecretParameters(t *testing.T) {
+	params := []*Parameter{
+		{Name: "module", Type: StringType},
+		{Name: "token", Type: StringType, Secret: true},
+	}
+	ref := TemplateReference{Name: "hello-world", Version: "1.0.0", Digest: "sha256:abc"}
+	p := NewProvenanceFile(ref, params, map[string]interface{}{"module": "hello", "token": "s3cr3t"})
+
+	assert.Equal(t, ref, p.Template)
+	assert.Equal(t, map[string]interface{}{"module": "hello"}, p.Parameters)
+}
+
+func TestWriteAndLoadProvenanceFile(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	f := filepath.Join(tmpDir, ProvenanceFileName)
+	ref := TemplateReference{Name: "hello-world", Version: "1.0.0", Digest: "sha256:abc", SourceURL: "http://my.repo.com/hello-world-1.0.0.zip"}
+	replacements := map[string]interface{}{
+		"module":   "hello",
+		"port":     8080,
+		"services": []string{"api", "worker"},
+		"labels":   map[string]string{"team": "core"},
+	}
+	err := NewProvenanceFile(ref, []*Parameter{}, replacements).WriteFile(f, 0644)
+	assert.Nil(t, err)
+
+	p, err := LoadProvenanceFile(f)
+
+	assert.Nil(t, err)
+	assert.Equal(t, ref, p.Template)
+	assert.Equal(t, map[string]string{"module": "hello", "port": "8080", "services": "api,worker", "labels": "team=core"}, p.Answers())
+}
+
+func TestWriteAndLoadProvenanceFileWithDependencies(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	f := filepath.Join(tmpDir, ProvenanceFileName)
+	ref := TemplateReference{Name: "hello-world", Version: "1.0.0", Digest: "sha256:abc"}
+	dependencyRef := TemplateReference{Name: "ci", Version: "1.2.0", Digest: "sha256:def"}
+	params := []*Parameter{
+		{Name: "project", Type: StringType},
+		{Name: "token", Type: StringType, Secret: true},
+	}
+	p := NewProvenanceFile(ref, []*Parameter{}, map[string]interface{}{"name": "hello"})
+	p.Dependencies = []*DependencyReference{NewDependencyReference(dependencyRef, ".github", params, map[string]interface{}{"project": "hello", "token": "s3cr3t"})}
+	err := p.WriteFile(f, 0644)
+	assert.Nil(t, err)
+
+	loaded, err := LoadProvenanceFile(f)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*DependencyReference{{
+		TemplateReference: dependencyRef,
+		Directory:         ".github",
+		Parameters:        map[string]interface{}{"project": "hello"},
+	}}, loaded.Dependencies)
+}
+
+func TestLoadNonExistentProvenanceFile(t *testing.T) {
+	p, err := LoadProvenanceFile("/does/not/exist/.letsgopher.yaml")
+
+	assert.Nil(t, p)
+	assert.NotNil(t, err)
+}
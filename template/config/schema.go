@@ -0,0 +1,346 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ghodss/yaml"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// schemaRequired lists the properties which need to be provided for the types of a manifest.
+var schemaRequired = map[reflect.Type][]string{
+	reflect.TypeOf(ManifestFile{}):   {"version"},
+	reflect.TypeOf(Parameter{}):      {"name", "type"},
+	reflect.TypeOf(Dependency{}):     {"name", "version"},
+	reflect.TypeOf(ParentTemplate{}): {"name", "version"},
+}
+
+// schemaEnums lists the allowed values of properties, keyed by the type and JSON name of the property.
+var schemaEnums = map[reflect.Type]map[string][]string{
+	reflect.TypeOf(Parameter{}): {"type": parameterTypes},
+}
+
+// schemaScalars lists the properties holding values of parameters, keyed by the type and JSON name of the property.
+// Values may be written as any YAML scalar, e.g. defaultValue: 8080, and are loaded as strings.
+var schemaScalars = map[reflect.Type][]string{
+	reflect.TypeOf(Parameter{}):  {"defaultValue", "fallback", "enum"},
+	reflect.TypeOf(Dependency{}): {"parameters"},
+}
+
+// scalarTypes are the JSON types of a value of a parameter.
+var scalarTypes = []string{"string", "number", "boolean"}
+
+// JSONSchema generates a JSON Schema (draft-07) for manifest files from the types representing a manifest.
+// Editors can use the schema to validate and autocomplete a manifest.yaml.
+func JSONSchema() ([]byte, error) {
+	return json.MarshalIndent(manifestSchema(), "", "  ")
+}
+
+func manifestSchema() map[string]interface{} {
+	g := &schemaGenerator{definitions: make(map[string]interface{})}
+	schema := g.object(reflect.TypeOf(ManifestFile{}))
+	schema["$schema"] = jsonSchemaDraft
+	schema["title"] = "letsgopher template manifest"
+	schema["definitions"] = g.definitions
+	return schema
+}
+
+type schemaGenerator struct {
+	definitions map[string]interface{}
+}
+
+func (g *schemaGenerator) object(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	g.addProperties(t, properties)
+	schema := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if required, ok := schemaRequired[t]; ok {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func (g *schemaGenerator) addProperties(t reflect.Type, properties map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			g.addProperties(f.Type, properties)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		property := g.property(f.Type)
+		if isScalarProperty(t, name) {
+			property = scalarProperty(f.Type)
+		}
+		if enum, ok := schemaEnums[t][name]; ok {
+			property["enum"] = enum
+		}
+		properties[name] = property
+	}
+}
+
+func (g *schemaGenerator) property(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.property(t.Elem())
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": g.property(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": g.property(t.Elem())}
+	case reflect.Struct:
+		if _, ok := g.definitions[t.Name()]; !ok {
+			g.definitions[t.Name()] = nil
+			g.definitions[t.Name()] = g.object(t)
+		}
+		return map[string]interface{}{"$ref": "#/definitions/" + t.Name()}
+	}
+	return map[string]interface{}{}
+}
+
+func isScalarProperty(t reflect.Type, name string) bool {
+	for _, scalar := range schemaScalars[t] {
+		if scalar == name {
+			return true
+		}
+	}
+	return false
+}
+
+// scalarProperty describes a property holding values of parameters, or a list or map of them.
+func scalarProperty(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": scalarProperty(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": scalarProperty(t.Elem())}
+	}
+	return map[string]interface{}{"type": scalarTypes}
+}
+
+// CheckSchema verifies YAML content of a manifest file against the JSON Schema of the manifest.
+// Unlike ValidateManifest it reports all elements violating the schema, including properties unknown to letsgopher.
+// An error is returned if the content isn't valid YAML.
+func CheckSchema(content []byte) ([]*ManifestError, error) {
+	j, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	err = json.Unmarshal(j, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	document = loadedScalars(document, reflect.TypeOf(ManifestFile{}))
+
+	schema := manifestSchema()
+	v := &schemaValidator{definitions: schema["definitions"].(map[string]interface{})}
+	v.validate(schema, document, []interface{}{})
+	for _, e := range v.errs {
+		e.Line = lineOf(content, e.Path)
+	}
+	return v.errs, nil
+}
+
+type schemaValidator struct {
+	definitions map[string]interface{}
+	errs        []*ManifestError
+}
+
+func (v *schemaValidator) validate(schema map[string]interface{}, value interface{}, segments []interface{}) {
+	if ref, ok := schema["$ref"].(string); ok {
+		schema = v.definitions[strings.TrimPrefix(ref, "#/definitions/")].(map[string]interface{})
+	}
+	if value == nil {
+		return
+	}
+	if types, ok := schema["type"].([]string); ok {
+		if !hasAnyType(types, value) {
+			v.fail(segments, fmt.Sprintf("needs to be one of the types %s", strings.Join(types, ", ")))
+		}
+		return
+	}
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			v.fail(segments, "needs to be an object")
+			return
+		}
+		v.validateObject(schema, object, segments)
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			v.fail(segments, "needs to be an array")
+			return
+		}
+		for i, item := range array {
+			v.validate(schema["items"].(map[string]interface{}), item, append(segments[:len(segments):len(segments)], i))
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			v.fail(segments, "needs to be a string")
+			return
+		}
+		if enum, ok := schema["enum"].([]string); ok && !isEnumValue(enum, s) {
+			v.fail(segments, fmt.Sprintf("needs to be one of %s", strings.Join(enum, ", ")))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			v.fail(segments, "needs to be a boolean")
+		}
+	case "integer":
+		if n, ok := value.(float64); !ok || n != math.Trunc(n) {
+			v.fail(segments, "needs to be an integer")
+		}
+	}
+}
+
+func (v *schemaValidator) validateObject(schema map[string]interface{}, object map[string]interface{}, segments []interface{}) {
+	if required, ok := schema["required"].([]string); ok {
+		for _, name := range required {
+			if _, ok := object[name]; !ok {
+				v.fail(segments, fmt.Sprintf("needs to provide the property %q", name))
+			}
+		}
+	}
+	names := []string{}
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	properties, _ := schema["properties"].(map[string]interface{})
+	for _, name := range names {
+		path := append(segments[:len(segments):len(segments)], name)
+		if property, ok := properties[name]; ok {
+			v.validate(property.(map[string]interface{}), object[name], path)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case map[string]interface{}:
+			v.validate(additional, object[name], path)
+		case bool:
+			if !additional {
+				v.fail(path, fmt.Sprintf("unknown property %q", name))
+			}
+		}
+	}
+}
+
+func (v *schemaValidator) fail(segments []interface{}, reason string) {
+	v.errs = append(v.errs, &ManifestError{Path: pointer(segments...), Err: errors.New(reason)})
+}
+
+// loadedScalars converts numbers and booleans of string fields to strings the same way LoadManifestData does,
+// e.g. prompt: n is parsed as boolean by YAML and loaded as "false". Like the loader, fields of embedded structs aren't converted.
+func loadedScalars(value interface{}, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, e := range v {
+			switch t.Kind() {
+			case reflect.Struct:
+				if f, ok := jsonField(t, name); ok {
+					v[name] = loadedScalars(e, f.Type)
+				}
+			case reflect.Map:
+				v[name] = loadedScalars(e, t.Elem())
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice {
+			for i, e := range v {
+				v[i] = loadedScalars(e, t.Elem())
+			}
+		}
+	case float64:
+		if t.Kind() == reflect.String {
+			return strconv.FormatFloat(v, 'g', -1, 64)
+		}
+	case bool:
+		if t.Kind() == reflect.String {
+			return strconv.FormatBool(v)
+		}
+	}
+	return value
+}
+
+// jsonField finds the field of a struct a JSON property is unmarshaled into.
+// The property of an embedded struct resolves to the embedded struct itself.
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if _, ok := jsonField(f.Type, name); ok {
+				return f, true
+			}
+			continue
+		}
+		tag := strings.Split(f.Tag.Get("json"), ",")[0]
+		if tag == "" {
+			tag = f.Name
+		}
+		if f.PkgPath == "" && tag == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// hasAnyType determines whether a JSON value is of one of the given JSON types.
+func hasAnyType(types []string, value interface{}) bool {
+	for _, t := range types {
+		switch value.(type) {
+		case string:
+			if t == "string" {
+				return true
+			}
+		case float64:
+			if t == "number" {
+				return true
+			}
+		case bool:
+			if t == "boolean" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isEnumValue(enum []string, s string) bool {
+	for _, e := range enum {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestJSONSchema(t *testing.T) {
+	b, err := JSONSchema()
+	assert.Nil(t, err)
+
+	var schema map[string]interface{}
+	err = json.Unmarshal(b, &schema)
+	assert.Nil(t, err)
+	assert.Equal(t, jsonSchemaDraft, schema["$schema"])
+	assert.Equal(t, []interface{}{"version"}, schema["required"])
+	assert.Equal(t, false, schema["additionalProperties"])
+
+	properties := schema["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "string"}, properties["version"])
+	assert.Equal(t, map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}, properties["authors"])
+	assert.Equal(t, map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#/definitions/Parameter"}}, properties["parameters"])
+	assert.Equal(t, map[string]interface{}{"$ref": "#/definitions/ParentTemplate"}, properties["extends"])
+	assert.NotContains(t, properties, "Metadata")
+	assert.NotContains(t, properties, "source")
+
+	definitions := schema["definitions"].(map[string]interface{})
+	assert.Len(t, definitions, 5)
+	parameter := definitions["Parameter"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"name", "type"}, parameter["required"])
+	parameterProperties := parameter["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "integer"}, parameterProperties["minLength"])
+	assert.Len(t, parameterProperties["type"].(map[string]interface{})["enum"], len(parameterTypes))
+	scalar := map[string]interface{}{"type": []interface{}{"string", "number", "boolean"}}
+	assert.Equal(t, scalar, parameterProperties["defaultValue"])
+	assert.Equal(t, scalar, parameterProperties["fallback"])
+	assert.Equal(t, map[string]interface{}{"type": "array", "items": scalar}, parameterProperties["enum"])
+	dependency := definitions["Dependency"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "object", "additionalProperties": scalar}, dependency["properties"].(map[string]interface{})["parameters"])
+}
+
+func TestCheckSchemaForValidManifest(t *testing.T) {
+	errs, err := CheckSchema([]byte(`version: "2.0.0"
+name: "hello-world"
+authors: ["Jane Doe"]
+parameters:
+  - name: "port"
+    type: "integer"
+    min: 1
+    defaultValue: 8080
+    enum: [8080, 9090]
+  - name: "debug"
+    type: "boolean"
+    when: "{{ .port }}"
+    fallback: false
+dependencies:
+  - name: "ci"
+    version: "1.x"
+    parameters:
+      project: "{{ .name }}"
+      port: 8080`))
+
+	assert.Nil(t, err)
+	assert.Empty(t, errs)
+}
+
+func TestCheckSchemaAcceptsScalarsOfStringPropertiesLikeLoader(t *testing.T) {
+	content := []byte(`version: "1.0.0"
+parameters:
+  - name: "confirm"
+    type: "boolean"
+    prompt: n
+    description: 42`)
+
+	errs, err := CheckSchema(content)
+
+	assert.Nil(t, err)
+	assert.Empty(t, errs)
+	m, err := LoadManifestData(content)
+	assert.Nil(t, err)
+	assert.Nil(t, ValidateManifest(m))
+	assert.Equal(t, "false", m.Parameters[0].Prompt)
+}
+
+func TestCheckSchemaRejectsScalarsOfMetadataLikeLoader(t *testing.T) {
+	content := []byte(`version: "1.0.0"
+name: yes`)
+
+	errs, err := CheckSchema(content)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*ManifestError{{Path: "/name", Line: 2, Err: errors.New("needs to be a string")}}, errs)
+	_, err = LoadManifestData(content)
+	assert.NotNil(t, err)
+}
+
+func TestCheckSchemaReportsAllViolations(t *testing.T) {
+	errs, err := CheckSchema([]byte(`name: "hello-world"
+parameters:
+  - name: "ratio"
+    type: "float"
+    prompt: "Please provide a ratio"
+  - name: "port"
+    type: "integer"
+    min: "one"
+    optional: true
+    defaultValue: [8080]
+formatGoFiles: "yes"`))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*ManifestError{
+		{Path: "", Line: 0, Err: errors.New("needs to provide the property \"version\"")},
+		{Path: "/formatGoFiles", Line: 11, Err: errors.New("needs to be a boolean")},
+		{Path: "/parameters/0/type", Line: 4, Err: fmt.Errorf("needs to be one of %s", strings.Join(parameterTypes, ", "))},
+		{Path: "/parameters/1/defaultValue", Line: 10, Err: errors.New("needs to be one of the types string, number, boolean")},
+		{Path: "/parameters/1/min", Line: 8, Err: errors.New("needs to be an integer")},
+		{Path: "/parameters/1/optional", Line: 9, Err: errors.New("unknown property \"optional\"")},
+	}, errs)
+}
+
+func TestCheckSchemaForInvalidYAML(t *testing.T) {
+	errs, err := CheckSchema([]byte("version: [1"))
+
+	assert.NotNil(t, err)
+	assert.Nil(t, errs)
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"github.com/blang/semver"
+	"github.com/bmuschko/letsgopher/template/version"
 	"github.com/ghodss/yaml"
 	"io/ioutil"
 	"os"
@@ -18,6 +20,7 @@ type Template struct {
 	Name        string `json:"name"`
 	Version     string `json:"version"`
 	ArchivePath string `json:"archivePath"`
+	SourceURL   string `json:"sourceURL,omitempty"`
 }
 
 // NewTemplatesFile creates a local template registry file of type TemplatesFile.
@@ -64,6 +67,30 @@ func (r *TemplatesFile) Get(name string, version string) *Template {
 	return nil
 }
 
+// Latest retrieves the template with a given name and the highest version within a version range from the registry.
+func (r *TemplatesFile) Latest(name string, versionRange string) (*Template, error) {
+	rg, err := version.ParseRange(versionRange)
+	if err != nil {
+		return nil, err
+	}
+	var latest *Template
+	var latestVersion semver.Version
+	for _, rf := range r.Templates {
+		if rf.Name != name {
+			continue
+		}
+		v, err := version.Parse(rf.Version)
+		if err != nil || !rg(v) {
+			continue
+		}
+		if latest == nil || v.GT(latestVersion) {
+			latest = rf
+			latestVersion = v
+		}
+	}
+	return latest, nil
+}
+
 // Add adds a template to the registry.
 func (r *TemplatesFile) Add(re ...*Template) {
 	r.Templates = append(r.Templates, re...)
@@ -100,6 +100,26 @@ func TestGetUnknownTemplate(t *testing.T) {
 	assert.Exactly(t, templatesFile.Templates, []*Template{helloWorldTemplate})
 }
 
+func TestLatestTemplateWithinVersionRange(t *testing.T) {
+	templatesFile := NewTemplatesFile()
+	v1 := &Template{Name: "ci", Version: "1.2.0", ArchivePath: "/my/path/archive/ci-1.2.0.zip"}
+	v2 := &Template{Name: "ci", Version: "1.10.1", ArchivePath: "/my/path/archive/ci-1.10.1.zip"}
+	v3 := &Template{Name: "ci", Version: "2.0.0", ArchivePath: "/my/path/archive/ci-2.0.0.zip"}
+	other := &Template{Name: "hello-world", Version: "1.5.0", ArchivePath: "/my/path/archive/hello-world-1.5.0.zip"}
+	templatesFile.Add(v1, v2, v3, other)
+
+	found, err := templatesFile.Latest("ci", ">=1.2 <2")
+	assert.Nil(t, err)
+	assert.Equal(t, v2, found)
+
+	found, err = templatesFile.Latest("ci", ">=3")
+	assert.Nil(t, err)
+	assert.Nil(t, found)
+
+	_, err = templatesFile.Latest("ci", "latest")
+	assert.NotNil(t, err)
+}
+
 func TestUpdateRegisteredTemplate(t *testing.T) {
 	templatesFile := NewTemplatesFile()
 	helloWorldTemplate := &Template{Name: "hello-world", Version: "1.0.0", ArchivePath: "/my/path/archive/hello-world-1.0.0.zip"}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+// Validate checks a value converted by ParseValue against the validation rules of the parameter.
+// The custom validation message of the parameter is returned instead of the default message if defined.
+func (p *Parameter) Validate(value interface{}) error {
+	err := p.validate(value)
+	if err != nil && p.ValidationMessage != "" {
+		return errors.New(p.ValidationMessage)
+	}
+	return err
+}
+
+func (p *Parameter) validate(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		return p.validateString(v)
+	case []string:
+		for _, item := range v {
+			err := p.validateString(item)
+			if err != nil {
+				return err
+			}
+		}
+	case int:
+		return p.validateInteger(v)
+	}
+	return nil
+}
+
+func (p *Parameter) validateString(s string) error {
+	if p.Pattern != "" {
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(s) {
+			return fmt.Errorf("value %q does not match pattern %q", s, p.Pattern)
+		}
+	}
+	length := utf8.RuneCountInString(s)
+	if p.MinLength != nil && length < *p.MinLength {
+		return fmt.Errorf("value %q needs to be at least %d characters long", s, *p.MinLength)
+	}
+	if p.MaxLength != nil && length > *p.MaxLength {
+		return fmt.Errorf("value %q needs to be at most %d characters long", s, *p.MaxLength)
+	}
+	return nil
+}
+
+func (p *Parameter) validateInteger(i int) error {
+	if p.Min != nil && i < *p.Min {
+		return fmt.Errorf("value %d needs to be greater than or equal to %d", i, *p.Min)
+	}
+	if p.Max != nil && i > *p.Max {
+		return fmt.Errorf("value %d needs to be less than or equal to %d", i, *p.Max)
+	}
+	return nil
+}
+
+func validateRules(p *Parameter) error {
+	stringRules := p.Pattern != "" || p.MinLength != nil || p.MaxLength != nil
+	if stringRules && !supportsStringRules(p.Type) {
+		return fmt.Errorf("parameter %q of type %s does not support pattern or length rules", p.Name, p.Type)
+	}
+	if (p.Min != nil || p.Max != nil) && p.Type != IntegerType {
+		return fmt.Errorf("parameter %q of type %s does not support min or max rules", p.Name, p.Type)
+	}
+	if p.Pattern != "" {
+		_, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			return fmt.Errorf("parameter %q defines an invalid pattern: %s", p.Name, err)
+		}
+	}
+	if p.MinLength != nil && *p.MinLength < 0 || p.MaxLength != nil && *p.MaxLength < 0 {
+		return fmt.Errorf("parameter %q needs to define non-negative length rules", p.Name)
+	}
+	if p.MinLength != nil && p.MaxLength != nil && *p.MinLength > *p.MaxLength {
+		return fmt.Errorf("parameter %q defines a minLength greater than its maxLength", p.Name)
+	}
+	if p.Min != nil && p.Max != nil && *p.Min > *p.Max {
+		return fmt.Errorf("parameter %q defines a min greater than its max", p.Name)
+	}
+	return nil
+}
+
+func supportsStringRules(t string) bool {
+	switch t {
+	case IntegerType, BooleanType, MapType:
+		return false
+	}
+	return true
+}
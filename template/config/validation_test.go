@@ -0,0 +1,54 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidateForValuesSatisfyingRules(t *testing.T) {
+	values := []struct {
+		name  string
+		param *Parameter
+		value interface{}
+	}{
+		{"pattern", &Parameter{Type: StringType, Pattern: "^[a-z-]+$"}, "hello-world"},
+		{"length", &Parameter{Type: StringType, MinLength: intPtr(2), MaxLength: intPtr(5)}, "héllo"},
+		{"list items", &Parameter{Type: ListType, Pattern: "^[a-z]+$"}, []string{"api", "worker"}},
+		{"range", &Parameter{Type: IntegerType, Min: intPtr(1), Max: intPtr(65535)}, 8080},
+		{"no rules", &Parameter{Type: BooleanType}, true},
+	}
+	for _, v := range values {
+		t.Run(v.name, func(t *testing.T) {
+			assert.Nil(t, v.param.Validate(v.value))
+		})
+	}
+}
+
+func TestValidateForValuesViolatingRules(t *testing.T) {
+	values := []struct {
+		name         string
+		param        *Parameter
+		value        interface{}
+		errorMessage string
+	}{
+		{"pattern", &Parameter{Type: StringType, Pattern: "^[a-z-]+$"}, "Hello", "value \"Hello\" does not match pattern \"^[a-z-]+$\""},
+		{"min length", &Parameter{Type: StringType, MinLength: intPtr(3)}, "ab", "value \"ab\" needs to be at least 3 characters long"},
+		{"max length", &Parameter{Type: StringType, MaxLength: intPtr(3)}, "abcd", "value \"abcd\" needs to be at most 3 characters long"},
+		{"list items", &Parameter{Type: ListType, Pattern: "^[a-z]+$"}, []string{"api", "Worker"}, "value \"Worker\" does not match pattern \"^[a-z]+$\""},
+		{"min", &Parameter{Type: IntegerType, Min: intPtr(1)}, 0, "value 0 needs to be greater than or equal to 1"},
+		{"max", &Parameter{Type: IntegerType, Max: intPtr(10)}, 11, "value 11 needs to be less than or equal to 10"},
+		{"custom message", &Parameter{Type: StringType, Pattern: "^[a-z]+$", ValidationMessage: "use lower-case letters only"}, "ABC", "use lower-case letters only"},
+	}
+	for _, v := range values {
+		t.Run(v.name, func(t *testing.T) {
+			err := v.param.Validate(v.value)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, v.errorMessage, err.Error())
+		})
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
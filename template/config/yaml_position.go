@@ -0,0 +1,110 @@
+package config
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var yamlKeyPattern = regexp.MustCompile(`^("[^"]*"|'[^']*'|[^\s"'#:][^:#]*?)\s*:(\s+(.*))?$`)
+
+// yamlNode is a mapping entry or sequence item of a YAML document in block style.
+type yamlNode struct {
+	line     int
+	indent   int
+	key      string
+	item     bool
+	open     bool
+	children []*yamlNode
+}
+
+// lineOf determines the line of the YAML element addressed by a JSON pointer.
+// Only block mappings and sequences are tracked, elements inside flow collections resolve to the line of the enclosing entry.
+// Returns the line of the closest known ancestor if the element itself cannot be located, or 0 for the document root.
+func lineOf(source []byte, p string) int {
+	node := parseYAMLNodes(source)
+	line := 0
+	for _, segment := range splitPointer(p) {
+		node = node.child(segment)
+		if node == nil {
+			break
+		}
+		line = node.line
+	}
+	return line
+}
+
+func (n *yamlNode) child(segment string) *yamlNode {
+	index, err := strconv.Atoi(segment)
+	items := 0
+	for _, c := range n.children {
+		if c.item {
+			if err == nil && items == index {
+				return c
+			}
+			items++
+			continue
+		}
+		if c.key == segment {
+			return c
+		}
+	}
+	return nil
+}
+
+func parseYAMLNodes(source []byte) *yamlNode {
+	root := &yamlNode{indent: -1, open: true}
+	stack := []*yamlNode{root}
+	blockIndent := -1
+	for i, l := range strings.Split(string(source), "\n") {
+		content := strings.TrimLeft(l, " ")
+		indent := len(l) - len(content)
+		if blockIndent >= 0 {
+			if strings.TrimSpace(content) == "" || indent > blockIndent {
+				continue
+			}
+			blockIndent = -1
+		}
+		if content == "" || strings.HasPrefix(content, "#") || content == "---" {
+			continue
+		}
+
+		for {
+			item := content == "-" || strings.HasPrefix(content, "- ")
+			var node *yamlNode
+			if item {
+				node = &yamlNode{line: i + 1, indent: indent, item: true, open: true}
+			} else {
+				m := yamlKeyPattern.FindStringSubmatch(content)
+				if m == nil {
+					break
+				}
+				value := strings.TrimSpace(m[3])
+				node = &yamlNode{line: i + 1, indent: indent, key: strings.Trim(m[1], `"'`), open: value == "" || strings.HasPrefix(value, "#")}
+				if strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") {
+					blockIndent = indent
+				}
+			}
+			for len(stack) > 1 {
+				top := stack[len(stack)-1]
+				if top.indent < indent || (top.indent == indent && item && !top.item && top.open) {
+					break
+				}
+				stack = stack[:len(stack)-1]
+			}
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, node)
+			stack = append(stack, node)
+			if !item {
+				break
+			}
+			rest := strings.TrimLeft(strings.TrimPrefix(content, "-"), " ")
+			if rest == "" {
+				break
+			}
+			indent += len(content) - len(rest)
+			content = rest
+		}
+	}
+	return root
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const positionManifest = `version: "1.0.0"
+# parameters of the template
+parameters:
+  - name: "name"
+    type: "string"
+  - name: "license"
+    type: "string"
+    description: |
+      The license of the project.
+      type: ignored
+hooks:
+  postGenerate:
+  - command: ["go", "mod", "tidy"]
+    workingDir: "../outside"
+"a/b": "escaped"
+`
+
+func TestLineOf(t *testing.T) {
+	pointers := []struct {
+		pointer string
+		line    int
+	}{
+		{"", 0},
+		{"/version", 1},
+		{"/parameters", 3},
+		{"/parameters/0", 4},
+		{"/parameters/0/type", 5},
+		{"/parameters/1/type", 7},
+		{"/parameters/1/description", 8},
+		{"/hooks/postGenerate/0", 13},
+		{"/hooks/postGenerate/0/command/1", 13},
+		{"/hooks/postGenerate/0/workingDir", 14},
+		{"/a~1b", 15},
+		{"/parameters/2", 3},
+		{"/unknown", 0},
+	}
+
+	for _, p := range pointers {
+		t.Run(p.pointer, func(t *testing.T) {
+			assert.Equal(t, p.line, lineOf([]byte(positionManifest), p.pointer))
+		})
+	}
+}
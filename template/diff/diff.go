@@ -0,0 +1,185 @@
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	contextLines  = 3
+	noNewlineNote = "\\ No newline at end of file\n"
+)
+
+type opKind byte
+
+const (
+	equalOp  opKind = ' '
+	deleteOp opKind = '-'
+	insertOp opKind = '+'
+)
+
+type op struct {
+	kind opKind
+	line string
+	// aLine and bLine are the zero-based positions of the line in a and b at the time of the operation.
+	aLine int
+	bLine int
+}
+
+// Match determines the longest common subsequence of two line slices.
+// The returned slice maps each line of a to the index of its counterpart in b or -1 if it has no counterpart.
+func Match(a []string, b []string) []int {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	m := make([]int, len(a))
+	i, j := 0, 0
+	for i < len(a) {
+		switch {
+		case j < len(b) && a[i] == b[j]:
+			m[i] = j
+			i++
+			j++
+		case j < len(b) && lcs[i][j+1] > lcs[i+1][j]:
+			j++
+		default:
+			m[i] = -1
+			i++
+		}
+	}
+	return m
+}
+
+// SplitLines splits content into lines keeping the line terminators.
+func SplitLines(content []byte) []string {
+	lines := []string{}
+	for _, l := range bytes.SplitAfter(content, []byte("\n")) {
+		if len(l) > 0 {
+			lines = append(lines, string(l))
+		}
+	}
+	return lines
+}
+
+// Stat counts the lines inserted and deleted when changing a into b.
+func Stat(a []byte, b []byte) (int, int) {
+	insertions, deletions := 0, 0
+	for _, o := range edits(SplitLines(a), SplitLines(b)) {
+		switch o.kind {
+		case insertOp:
+			insertions++
+		case deleteOp:
+			deletions++
+		}
+	}
+	return insertions, deletions
+}
+
+// Unified renders the changes from a to b in the unified diff format with three lines of context.
+// It returns an empty string if both contents are equal.
+func Unified(aName string, bName string, a []byte, b []byte) string {
+	ops := edits(SplitLines(a), SplitLines(b))
+	out := bytes.NewBuffer(nil)
+	for start := 0; start < len(ops); {
+		if ops[start].kind == equalOp {
+			start++
+			continue
+		}
+		if out.Len() == 0 {
+			fmt.Fprintf(out, "--- %s\n+++ %s\n", aName, bName)
+		}
+		from := start - contextLines
+		if from < 0 {
+			from = 0
+		}
+		to := hunkEnd(ops, start)
+		writeHunk(out, ops[from:to])
+		start = to
+	}
+	return out.String()
+}
+
+// hunkEnd determines the end of a hunk starting with a change.
+// Changes separated by no more than twice the number of context lines are combined into the same hunk.
+func hunkEnd(ops []op, start int) int {
+	end := start
+	for i := start; i < len(ops); i++ {
+		if ops[i].kind != equalOp {
+			end = i + 1
+			continue
+		}
+		if i-end >= 2*contextLines {
+			break
+		}
+	}
+	end += contextLines
+	if end > len(ops) {
+		end = len(ops)
+	}
+	return end
+}
+
+func writeHunk(out *bytes.Buffer, ops []op) {
+	aCount, bCount := 0, 0
+	for _, o := range ops {
+		if o.kind != insertOp {
+			aCount++
+		}
+		if o.kind != deleteOp {
+			bCount++
+		}
+	}
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(ops[0].aLine, aCount), hunkRange(ops[0].bLine, bCount))
+	for _, o := range ops {
+		out.WriteByte(byte(o.kind))
+		out.WriteString(o.line)
+		if !strings.HasSuffix(o.line, "\n") {
+			out.WriteString("\n" + noNewlineNote)
+		}
+	}
+}
+
+func hunkRange(start int, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func edits(a []string, b []string) []op {
+	m := Match(a, b)
+	ops := []op{}
+	j := 0
+	for i, line := range a {
+		if m[i] < 0 {
+			ops = append(ops, op{kind: deleteOp, line: line, aLine: i, bLine: j})
+			continue
+		}
+		for ; j < m[i]; j++ {
+			ops = append(ops, op{kind: insertOp, line: b[j], aLine: i, bLine: j})
+		}
+		ops = append(ops, op{kind: equalOp, line: line, aLine: i, bLine: j})
+		j++
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{kind: insertOp, line: b[j], aLine: len(a), bLine: j})
+	}
+	return ops
+}
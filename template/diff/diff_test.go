@@ -0,0 +1,68 @@
+package diff
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUnifiedForEqualContent(t *testing.T) {
+	assert.Equal(t, "", Unified("a/file", "b/file", []byte("a\nb\n"), []byte("a\nb\n")))
+}
+
+func TestUnifiedWithContext(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n16\n17\n18\n19\n20\n"
+	b := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n16\n17\n18\n19\n20\n21\n"
+
+	assert.Equal(t, `--- a/file
++++ b/file
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+@@ -18,3 +18,4 @@
+ 18
+ 19
+ 20
++21
+`, Unified("a/file", "b/file", []byte(a), []byte(b)))
+}
+
+func TestUnifiedCombinesCloseChanges(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n"
+	b := "one\n2\n3\n4\n5\n6\n7\neight\n"
+
+	assert.Equal(t, `--- a/file
++++ b/file
+@@ -1,8 +1,8 @@
+-1
++one
+ 2
+ 3
+ 4
+ 5
+ 6
+ 7
+-8
++eight
+`, Unified("a/file", "b/file", []byte(a), []byte(b)))
+}
+
+func TestUnifiedForNewAndMissingNewline(t *testing.T) {
+	assert.Equal(t, "--- /dev/null\n+++ b/file\n@@ -0,0 +1 @@\n+new\n", Unified("/dev/null", "b/file", nil, []byte("new\n")))
+	assert.Equal(t, "--- a/file\n+++ b/file\n@@ -1 +1 @@\n-old\n\\ No newline at end of file\n+new\n", Unified("a/file", "b/file", []byte("old"), []byte("new\n")))
+}
+
+func TestStat(t *testing.T) {
+	insertions, deletions := Stat([]byte("a\nb\nc\n"), []byte("a\nB\nc\nd\n"))
+
+	assert.Equal(t, 2, insertions)
+	assert.Equal(t, 1, deletions)
+}
+
+func TestMatch(t *testing.T) {
+	assert.Equal(t, []int{0, -1, 1}, Match([]string{"a", "b", "c"}, []string{"a", "c", "d"}))
+}
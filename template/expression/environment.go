@@ -0,0 +1,58 @@
+package expression
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	gitConfig = func(key string) string {
+		return command("git", "config", "--get", key)
+	}
+	goVersionOutput = func() string {
+		return command("go", "version")
+	}
+	now   = time.Now
+	getwd = os.Getwd
+)
+
+func gitUserName() string {
+	return gitConfig("user.name")
+}
+
+func gitUserEmail() string {
+	return gitConfig("user.email")
+}
+
+func currentDir() string {
+	dir, err := getwd()
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(dir)
+}
+
+func year() string {
+	return strconv.Itoa(now().Year())
+}
+
+// goVersion returns the version of the installed Go toolchain without the "go" prefix, e.g. "1.21.3".
+func goVersion() string {
+	fields := strings.Fields(goVersionOutput())
+	if len(fields) < 3 {
+		return ""
+	}
+	return strings.TrimPrefix(fields[2], "go")
+}
+
+func command(name string, args ...string) string {
+	out, err := exec.Command(name, args...).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
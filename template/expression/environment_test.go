@@ -0,0 +1,60 @@
+package expression
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestEnvironmentFunctions(t *testing.T) {
+	defer stubEnvironment()()
+
+	data := map[string]interface{}{}
+	expressions := []struct {
+		expr     string
+		expected string
+	}{
+		{"gitUserName", "Gopher"},
+		{"gitUserEmail", "gopher@example.com"},
+		{"currentDir", "services"},
+		{"year", "2019"},
+		{"goVersion", "1.12.1"},
+		{"Copyright {{ year }} {{ gitUserName }}", "Copyright 2019 Gopher"},
+	}
+	for _, e := range expressions {
+		t.Run(e.expr, func(t *testing.T) {
+			s, err := Evaluate(e.expr, data)
+
+			assert.Nil(t, err)
+			assert.Equal(t, e.expected, s)
+		})
+	}
+}
+
+func TestGoVersionForMissingToolchain(t *testing.T) {
+	defer stubEnvironment()()
+	goVersionOutput = func() string {
+		return ""
+	}
+
+	assert.Equal(t, "", goVersion())
+}
+
+func stubEnvironment() func() {
+	origGitConfig, origGoVersionOutput, origNow, origGetwd := gitConfig, goVersionOutput, now, getwd
+	gitConfig = func(key string) string {
+		return map[string]string{"user.name": "Gopher", "user.email": "gopher@example.com"}[key]
+	}
+	goVersionOutput = func() string {
+		return "go version go1.12.1 darwin/amd64"
+	}
+	now = func() time.Time {
+		return time.Date(2019, time.April, 1, 0, 0, 0, 0, time.UTC)
+	}
+	getwd = func() (string, error) {
+		return "/home/gopher/services", nil
+	}
+	return func() {
+		gitConfig, goVersionOutput, now, getwd = origGitConfig, origGoVersionOutput, origNow, origGetwd
+	}
+}
@@ -0,0 +1,177 @@
+package expression
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+const actionDelim = "{{"
+
+// Evaluate renders an expression using Go's templating functionality against the given data.
+// Expressions not containing an action are treated as a single pipeline, e.g. ".name" is evaluated as "{{ .name }}".
+func Evaluate(expr string, data map[string]interface{}) (string, error) {
+	return Render(wrap(expr), data)
+}
+
+// Render renders text containing actions using Go's templating functionality against the given data.
+// Text without actions is returned unchanged. Missing values render as empty string.
+func Render(text string, data map[string]interface{}) (string, error) {
+	t, err := parseTemplate(text)
+	if err != nil {
+		return "", err
+	}
+	buf := bytes.NewBuffer(nil)
+	err = t.Execute(buf, withMissingFields(t.Tree, data))
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// withMissingFields returns a copy of the data setting the fields referenced by the parse tree without a value to an empty string.
+// Otherwise missing values would render as "<no value>". A missing field referenced by a chain, e.g. .a.b, is set to a map
+// providing the chained fields so that the chain evaluates to an empty string instead of failing.
+func withMissingFields(tree *parse.Tree, data map[string]interface{}) map[string]interface{} {
+	fields := make(fieldTree)
+	collectFields(tree.Root, fields)
+	values := make(map[string]interface{})
+	for k, v := range data {
+		values[k] = v
+	}
+	for name, chained := range fields {
+		if _, exists := values[name]; !exists {
+			values[name] = chained.missingValue()
+		}
+	}
+	return values
+}
+
+// fieldTree holds the referenced fields by name and the fields chained to them, e.g. .a.b is represented as {a: {b: {}}}.
+type fieldTree map[string]fieldTree
+
+func (t fieldTree) add(idents []string) {
+	if len(idents) == 0 {
+		return
+	}
+	chained, exists := t[idents[0]]
+	if !exists {
+		chained = make(fieldTree)
+		t[idents[0]] = chained
+	}
+	chained.add(idents[1:])
+}
+
+func (t fieldTree) missingValue() interface{} {
+	if len(t) == 0 {
+		return ""
+	}
+	values := make(map[string]interface{})
+	for name, chained := range t {
+		values[name] = chained.missingValue()
+	}
+	return values
+}
+
+func collectFields(node parse.Node, fields fieldTree) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			collectFields(c, fields)
+		}
+	case *parse.ActionNode:
+		collectFields(n.Pipe, fields)
+	case *parse.IfNode:
+		collectBranchFields(&n.BranchNode, fields)
+	case *parse.WithNode:
+		collectBranchFields(&n.BranchNode, fields)
+	case *parse.RangeNode:
+		collectBranchFields(&n.BranchNode, fields)
+	case *parse.TemplateNode:
+		collectFields(n.Pipe, fields)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Cmds {
+			collectFields(c, fields)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectFields(arg, fields)
+		}
+	case *parse.ChainNode:
+		collectFields(n.Node, fields)
+	case *parse.FieldNode:
+		fields.add(n.Ident)
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			fields.add(n.Ident[1:])
+		}
+	}
+}
+
+func collectBranchFields(b *parse.BranchNode, fields fieldTree) {
+	collectFields(b.Pipe, fields)
+	collectFields(b.List, fields)
+	collectFields(b.ElseList, fields)
+}
+
+// EvaluateCondition determines whether a condition expression is true for the given data.
+// Pipelines follow the truth rules of Go's templating functionality, e.g. `and .useDatabase (eq .database "postgres")`.
+// Expressions containing actions are true unless they render to an empty string, "false" or "0".
+func EvaluateCondition(expr string, data map[string]interface{}) (bool, error) {
+	if strings.Contains(expr, actionDelim) {
+		s, err := Evaluate(expr, data)
+		if err != nil {
+			return false, err
+		}
+		s = strings.TrimSpace(s)
+		return s != "" && s != "false" && s != "0", nil
+	}
+	s, err := Evaluate("{{ if "+expr+" }}true{{ end }}", data)
+	if err != nil {
+		return false, err
+	}
+	return s == "true", nil
+}
+
+// Validate checks the syntax of an expression.
+func Validate(expr string) error {
+	return ValidateTemplate(wrap(expr))
+}
+
+// ValidateTemplate checks the syntax of text containing actions.
+func ValidateTemplate(text string) error {
+	_, err := parseTemplate(text)
+	return err
+}
+
+// Tree parses an expression or text containing actions and returns the parse tree for static analysis.
+func Tree(expr string) (*parse.Tree, error) {
+	t, err := parseTemplate(wrap(expr))
+	if err != nil {
+		return nil, err
+	}
+	return t.Tree, nil
+}
+
+// IsTemplate determines whether text contains actions.
+func IsTemplate(text string) bool {
+	return strings.Contains(text, actionDelim)
+}
+
+func wrap(expr string) string {
+	if strings.Contains(expr, actionDelim) {
+		return expr
+	}
+	return actionDelim + " " + expr + " }}"
+}
+
+func parseTemplate(text string) (*template.Template, error) {
+	return template.New("expression").Funcs(FuncMap()).Option("missingkey=zero").Parse(text)
+}
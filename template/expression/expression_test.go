@@ -0,0 +1,91 @@
+package expression
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEvaluatePipeline(t *testing.T) {
+	s, err := Evaluate(".name", map[string]interface{}{"name": "hello"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", s)
+}
+
+func TestEvaluateTemplate(t *testing.T) {
+	s, err := Evaluate("github.com/{{ .org }}/{{ .name }}", map[string]interface{}{"org": "bmuschko", "name": "hello"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "github.com/bmuschko/hello", s)
+}
+
+func TestRenderMissingValues(t *testing.T) {
+	s, err := Render("<no value> {{ .missing }}{{ $.other }}{{ if .flag }}set{{ end }}", map[string]interface{}{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "<no value> ", s)
+}
+
+func TestRenderMissingChainedValues(t *testing.T) {
+	s, err := Render("{{ if .a.b }}set{{ else }}unset{{ end }}|{{ .a.b }}|{{ $.c.d.e }}", map[string]interface{}{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "unset||", s)
+
+	s, err = Render("{{ if .a.b }}set{{ end }}", map[string]interface{}{"a": map[string]interface{}{"b": true}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "set", s)
+
+	ok, err := EvaluateCondition(".a.b", map[string]interface{}{})
+
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestEvaluateConditions(t *testing.T) {
+	data := map[string]interface{}{"useDatabase": true, "database": "postgres", "replicas": 0, "name": ""}
+	conditions := []struct {
+		expr     string
+		expected bool
+	}{
+		{".useDatabase", true},
+		{"not .useDatabase", false},
+		{`and .useDatabase (eq .database "postgres")`, true},
+		{`eq .database "mysql"`, false},
+		{".replicas", false},
+		{".name", false},
+		{".undefined", false},
+		{"{{ .useDatabase }}", true},
+		{"{{ .replicas }}", false},
+		{"{{ .database }}", true},
+	}
+	for _, c := range conditions {
+		t.Run(c.expr, func(t *testing.T) {
+			ok, err := EvaluateCondition(c.expr, data)
+
+			assert.Nil(t, err)
+			assert.Equal(t, c.expected, ok)
+		})
+	}
+}
+
+func TestEvaluateConditionWithInvalidExpression(t *testing.T) {
+	_, err := EvaluateCondition("eq .a", map[string]interface{}{"a": "b"})
+
+	assert.NotNil(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	assert.Nil(t, Validate(`and .a (eq .b "c")`))
+	assert.Nil(t, Validate("{{ .a }}-{{ .b }}"))
+	assert.NotNil(t, Validate("{{ .a "))
+	assert.NotNil(t, Validate("and .a )"))
+}
+
+func TestTree(t *testing.T) {
+	tree, err := Tree(`eq .database "postgres"`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{{eq .database "postgres"}}`, tree.Root.String())
+}
@@ -0,0 +1,130 @@
+package expression
+
+import (
+	"github.com/bmuschko/letsgopher/template/version"
+	"path"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// FuncMap returns the functions available to expressions and template files.
+// Besides string conversions it provides facts about the environment like the git user name and the installed Go version.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"lower":       strings.ToLower,
+		"upper":       strings.ToUpper,
+		"title":       strings.Title,
+		"trim":        strings.TrimSpace,
+		"replace":     replace,
+		"join":        join,
+		"base":        path.Base,
+		"kebabCase":   KebabCase,
+		"snakeCase":   SnakeCase,
+		"camelCase":   CamelCase,
+		"pascalCase":  PascalCase,
+		"packageName": PackageName,
+
+		"gitUserName":  gitUserName,
+		"gitUserEmail": gitUserEmail,
+		"currentDir":   currentDir,
+		"year":         year,
+		"goVersion":    goVersion,
+
+		"semverCompare": semverCompare,
+	}
+}
+
+// KebabCase converts a name into lower-case words separated by dashes, e.g. "HelloWorld" becomes "hello-world".
+func KebabCase(s string) string {
+	return strings.Join(lowerWords(s), "-")
+}
+
+// SnakeCase converts a name into lower-case words separated by underscores, e.g. "hello-world" becomes "hello_world".
+func SnakeCase(s string) string {
+	return strings.Join(lowerWords(s), "_")
+}
+
+// CamelCase converts a name into words joined by capitalizing all but the first word, e.g. "hello-world" becomes "helloWorld".
+func CamelCase(s string) string {
+	ws := lowerWords(s)
+	for i := 1; i < len(ws); i++ {
+		ws[i] = capitalize(ws[i])
+	}
+	return strings.Join(ws, "")
+}
+
+// PascalCase converts a name into capitalized words joined together, e.g. "hello-world" becomes "HelloWorld".
+func PascalCase(s string) string {
+	ws := lowerWords(s)
+	for i := range ws {
+		ws[i] = capitalize(ws[i])
+	}
+	return strings.Join(ws, "")
+}
+
+// PackageName converts a name into a valid Go package name, e.g. "my-service" becomes "myservice".
+// Names starting with a digit are prefixed with an underscore.
+func PackageName(s string) string {
+	name := strings.Join(lowerWords(path.Base(s)), "")
+	if name != "" && unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+	return name
+}
+
+// semverCompare determines whether a version lies within a version range, e.g. semverCompare ">=1.21" goVersion.
+func semverCompare(r string, v string) (bool, error) {
+	return version.Satisfies(v, r)
+}
+
+func replace(old string, new string, s string) string {
+	return strings.Replace(s, old, new, -1)
+}
+
+func join(sep string, items interface{}) string {
+	switch i := items.(type) {
+	case []string:
+		return strings.Join(i, sep)
+	case string:
+		return i
+	}
+	return ""
+}
+
+func lowerWords(s string) []string {
+	words := []string{}
+	current := []rune{}
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(current) > 0 {
+				words = append(words, string(current))
+				current = []rune{}
+			}
+			continue
+		}
+		if unicode.IsUpper(r) && len(current) > 0 {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				words = append(words, string(current))
+				current = []rune{}
+			}
+		}
+		current = append(current, unicode.ToLower(r))
+	}
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+	return words
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
@@ -0,0 +1,56 @@
+package expression
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCaseConversions(t *testing.T) {
+	names := []struct {
+		input  string
+		kebab  string
+		snake  string
+		camel  string
+		pascal string
+		pkg    string
+	}{
+		{"hello-world", "hello-world", "hello_world", "helloWorld", "HelloWorld", "helloworld"},
+		{"HelloWorld", "hello-world", "hello_world", "helloWorld", "HelloWorld", "helloworld"},
+		{"myHTTPServer", "my-http-server", "my_http_server", "myHttpServer", "MyHttpServer", "myhttpserver"},
+		{"order_service v2", "order-service-v2", "order_service_v2", "orderServiceV2", "OrderServiceV2", "orderservicev2"},
+		{"github.com/acme/3d-printer", "github-com-acme-3d-printer", "github_com_acme_3d_printer", "githubComAcme3dPrinter", "GithubComAcme3dPrinter", "_3dprinter"},
+	}
+	for _, n := range names {
+		t.Run(n.input, func(t *testing.T) {
+			assert.Equal(t, n.kebab, KebabCase(n.input))
+			assert.Equal(t, n.snake, SnakeCase(n.input))
+			assert.Equal(t, n.camel, CamelCase(n.input))
+			assert.Equal(t, n.pascal, PascalCase(n.input))
+			assert.Equal(t, n.pkg, PackageName(n.input))
+		})
+	}
+}
+
+func TestEvaluateWithFunctions(t *testing.T) {
+	data := map[string]interface{}{"org": "acme", "name": "order-service", "services": []string{"api", "worker"}}
+	expressions := []struct {
+		expr     string
+		expected string
+	}{
+		{"packageName .name", "orderservice"},
+		{"github.com/{{ .org }}/{{ .name }}", "github.com/acme/order-service"},
+		{"{{ .org }}/{{ .name | kebabCase }}:latest", "acme/order-service:latest"},
+		{`join "," .services`, "api,worker"},
+		{`replace "-" "." .name`, "order.service"},
+		{"upper .org", "ACME"},
+		{"{{ .missing }}", ""},
+	}
+	for _, e := range expressions {
+		t.Run(e.expr, func(t *testing.T) {
+			s, err := Evaluate(e.expr, data)
+
+			assert.Nil(t, err)
+			assert.Equal(t, e.expected, s)
+		})
+	}
+}
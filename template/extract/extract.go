@@ -0,0 +1,288 @@
+package extract
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/bmuschko/letsgopher/template/archive"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/bmuschko/letsgopher/template/gomod"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	gitIgnoreFile = ".gitignore"
+
+	// escapedDelimiter renders the left delimiter of a template action as literal text.
+	escapedDelimiter = `{{ "{{" }}`
+)
+
+var parameterNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Replacement turns every occurrence of a literal string of a project into a placeholder for a parameter.
+type Replacement struct {
+	Parameter string
+	Literal   string
+}
+
+// ParseReplacement parses a replacement defined as parameter name and literal separated by the = character.
+func ParseReplacement(s string) (*Replacement, error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("replacement %q needs to be defined as parameter=literal", s)
+	}
+	return &Replacement{Parameter: parts[0], Literal: parts[1]}, nil
+}
+
+// Extractor creates a template from an existing project.
+type Extractor struct {
+	// Name is the name of the template declared by the generated manifest.
+	Name         string
+	Replacements []*Replacement
+}
+
+// Result describes the template created from a project.
+type Result struct {
+	// Files lists the slash-separated paths of the template files, excluding the manifest.
+	Files []string
+
+	// Occurrences counts the replaced occurrences of the literal of each parameter in file contents and paths.
+	Occurrences map[string]int
+}
+
+// Extract copies the files of a project directory into a template directory.
+// Occurrences of the literals of all replacements are replaced with placeholders for their parameters in file contents and paths.
+// Longer literals take precedence over shorter literals starting at the same position.
+// Existing {{ sequences are escaped so that they are generated verbatim. Binary files are copied as they are.
+// Git metadata and files excluded by the .gitignore file of the project are skipped.
+// A manifest declaring a parameter for every replacement with the literal as default value is written to the template directory.
+func (e *Extractor) Extract(projectDir string, targetDir string) (*Result, error) {
+	err := e.validate(projectDir, targetDir)
+	if err != nil {
+		return nil, err
+	}
+	ignores, err := loadIgnoreList(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(targetDir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &replacer{replacements: sortedByLength(e.Replacements), occurrences: make(map[string]int)}
+	result := &Result{Files: []string{}, Occurrences: r.occurrences}
+	err = filepath.Walk(projectDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(projectDir, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		name := filepath.ToSlash(rel)
+		if ignores.Ignored(name, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("project file %q is a symbolic link which can't be extracted", name)
+		}
+		rendered := r.replace(name)
+		target := filepath.Join(targetDir, filepath.FromSlash(rendered))
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if !archive.IsBinary(b) {
+			b = []byte(r.replace(string(b)))
+		}
+		err = os.MkdirAll(filepath.Dir(target), 0755)
+		if err != nil {
+			return err
+		}
+		err = ioutil.WriteFile(target, b, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		result.Files = append(result.Files, rendered)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := e.manifest(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	err = ioutil.WriteFile(filepath.Join(targetDir, config.ManifestFileName), manifest, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (e *Extractor) validate(projectDir string, targetDir string) error {
+	if len(e.Replacements) == 0 {
+		return errors.New("at least one literal needs to be replaced with a parameter")
+	}
+	parameters := make(map[string]bool)
+	literals := make(map[string]bool)
+	for _, r := range e.Replacements {
+		if !parameterNamePattern.MatchString(r.Parameter) {
+			return fmt.Errorf("parameter name %q may only contain letters, digits and underscores and may not start with a digit", r.Parameter)
+		}
+		if r.Literal == "" {
+			return fmt.Errorf("parameter %q needs to replace a non-empty literal", r.Parameter)
+		}
+		if strings.Contains(r.Literal, "{{") || strings.Contains(r.Literal, "}}") {
+			return fmt.Errorf("literal %q of parameter %q may not contain template delimiters", r.Literal, r.Parameter)
+		}
+		if parameters[r.Parameter] {
+			return fmt.Errorf("parameter %q replaces more than one literal", r.Parameter)
+		}
+		if literals[r.Literal] {
+			return fmt.Errorf("literal %q is replaced by more than one parameter", r.Literal)
+		}
+		parameters[r.Parameter] = true
+		literals[r.Literal] = true
+	}
+
+	info, err := os.Stat(projectDir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("project %q needs to be a directory", projectDir)
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, config.ManifestFileName)); err == nil {
+		return fmt.Errorf("project %q already contains a %s file", projectDir, config.ManifestFileName)
+	}
+	absProject, err := filepath.Abs(projectDir)
+	if err != nil {
+		return err
+	}
+	absTarget, err := filepath.Abs(targetDir)
+	if err != nil {
+		return err
+	}
+	if absTarget == absProject || strings.HasPrefix(absTarget, absProject+string(filepath.Separator)) {
+		return fmt.Errorf("template directory %q may not be located within the project directory", targetDir)
+	}
+	return nil
+}
+
+// manifest renders the manifest of the template. The literal replaced by a parameter becomes its default value.
+// A parameter replacing the module path declared by the go.mod file of the project is of type modulePath.
+func (e *Extractor) manifest(projectDir string) ([]byte, error) {
+	modulePath := ""
+	b, err := ioutil.ReadFile(filepath.Join(projectDir, gomod.FileName))
+	if err == nil {
+		if f, err := gomod.Parse(b); err == nil {
+			modulePath = f.Module
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	absProject, err := filepath.Abs(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(nil)
+	buf.WriteString("version: \"2.0.0\"\n")
+	if e.Name != "" {
+		fmt.Fprintf(buf, "name: %s\n", strconv.Quote(e.Name))
+	}
+	fmt.Fprintf(buf, "description: %s\n", strconv.Quote("Extracted from the project "+filepath.Base(absProject)))
+	buf.WriteString("parameters:\n")
+	for _, r := range e.Replacements {
+		paramType := config.StringType
+		if r.Literal == modulePath {
+			paramType = config.ModulePathType
+		}
+		fmt.Fprintf(buf, "  - name: %s\n", strconv.Quote(r.Parameter))
+		fmt.Fprintf(buf, "    prompt: %s\n", strconv.Quote("Please provide the value of "+r.Parameter))
+		fmt.Fprintf(buf, "    type: %s\n", strconv.Quote(paramType))
+		fmt.Fprintf(buf, "    defaultValue: %s\n", strconv.Quote(r.Literal))
+	}
+
+	m, err := config.LoadManifestData(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	err = config.ValidateManifest(m)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// replacer replaces literals with placeholders in a single pass, so that placeholders are never replaced again.
+type replacer struct {
+	replacements []*Replacement
+	occurrences  map[string]int
+}
+
+func (r *replacer) replace(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if strings.HasPrefix(s[i:], "{{") {
+			b.WriteString(escapedDelimiter)
+			i += 2
+			continue
+		}
+		matched := false
+		for _, rep := range r.replacements {
+			if strings.HasPrefix(s[i:], rep.Literal) {
+				b.WriteString("{{ ." + rep.Parameter + " }}")
+				r.occurrences[rep.Parameter]++
+				i += len(rep.Literal)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			b.WriteByte(s[i])
+			i++
+		}
+	}
+	return b.String()
+}
+
+func sortedByLength(replacements []*Replacement) []*Replacement {
+	sorted := append([]*Replacement{}, replacements...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i].Literal) > len(sorted[j].Literal)
+	})
+	return sorted
+}
+
+func loadIgnoreList(projectDir string) (*archive.IgnoreList, error) {
+	ignores := &archive.IgnoreList{}
+	b, err := ioutil.ReadFile(filepath.Join(projectDir, gitIgnoreFile))
+	if err == nil {
+		ignores = archive.ParseIgnoreList(b)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	ignores.Add(".git/")
+	return ignores, nil
+}
@@ -0,0 +1,131 @@
+package extract
+
+import (
+	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/template/archive"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/bmuschko/letsgopher/testhelper"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	projectDir := filepath.Join(tmpDir, "orders")
+	testhelper.WriteFiles(t, projectDir, map[string]string{
+		".gitignore":               "/bin/\n",
+		".git/HEAD":                "ref: refs/heads/master\n",
+		"bin/orders":               "binary",
+		"go.mod":                   "module github.com/acme/orders\n\ngo 1.13\n",
+		"cmd/orders/main.go":       "package main\n\nimport \"github.com/acme/orders/internal/api\"\n\nfunc main() {\n\tapi.Serve(\"orders\")\n}\n",
+		"deploy/chart/values.yaml": "image: acme/orders\nlabel: {{ .Values.name }}\n",
+		"logo.png":                 "\x89PNG\x00orders",
+	})
+	extractor := &Extractor{
+		Name: "orders-service",
+		Replacements: []*Replacement{
+			{Parameter: "module", Literal: "github.com/acme/orders"},
+			{Parameter: "appName", Literal: "orders"},
+			{Parameter: "org", Literal: "acme"},
+		},
+	}
+	targetDir := filepath.Join(tmpDir, "orders-template")
+	result, err := extractor.Extract(projectDir, targetDir)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{".gitignore", "cmd/{{ .appName }}/main.go", "deploy/chart/values.yaml", "go.mod", "logo.png"}, result.Files)
+	assert.Equal(t, map[string]int{"module": 2, "appName": 4, "org": 1}, result.Occurrences)
+	assert.Equal(t, "module {{ .module }}\n\ngo 1.13\n", testhelper.ReadFile(t, filepath.Join(targetDir, "go.mod")))
+	assert.Equal(t, "package main\n\nimport \"{{ .module }}/internal/api\"\n\nfunc main() {\n\tapi.Serve(\"{{ .appName }}\")\n}\n", testhelper.ReadFile(t, filepath.Join(targetDir, "cmd", "{{ .appName }}", "main.go")))
+	assert.Equal(t, "image: {{ .org }}/{{ .appName }}\nlabel: {{ \"{{\" }} .Values.name }}\n", testhelper.ReadFile(t, filepath.Join(targetDir, "deploy", "chart", "values.yaml")))
+	assert.Equal(t, "\x89PNG\x00orders", testhelper.ReadFile(t, filepath.Join(targetDir, "logo.png")))
+	testhelper.FileNotExists(t, filepath.Join(targetDir, "bin"))
+	testhelper.FileNotExists(t, filepath.Join(targetDir, ".git"))
+
+	m, err := config.LoadManifestData([]byte(testhelper.ReadFile(t, filepath.Join(targetDir, "manifest.yaml"))))
+	assert.Nil(t, err)
+	assert.Equal(t, "orders-service", m.Name)
+	assert.Equal(t, "Extracted from the project orders", m.Description)
+	assert.Equal(t, []*config.Parameter{
+		{Name: "module", Prompt: "Please provide the value of module", Type: "modulePath", DefaultValue: "github.com/acme/orders"},
+		{Name: "appName", Prompt: "Please provide the value of appName", Type: "string", DefaultValue: "orders"},
+		{Name: "org", Prompt: "Please provide the value of org", Type: "string", DefaultValue: "acme"},
+	}, m.Parameters)
+}
+
+func TestExtractedTemplateGeneratesProject(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	projectDir := filepath.Join(tmpDir, "orders")
+	files := map[string]string{
+		"go.mod":             "module github.com/acme/orders\n",
+		"cmd/orders/main.go": "package main\n\n// Serves {{orders}} of acme.\nfunc main() {}\n",
+	}
+	testhelper.WriteFiles(t, projectDir, files)
+	extractor := &Extractor{Replacements: []*Replacement{{Parameter: "appName", Literal: "orders"}, {Parameter: "org", Literal: "acme"}}}
+	targetDir := filepath.Join(tmpDir, "orders-template")
+	_, err := extractor.Extract(projectDir, targetDir)
+	assert.Nil(t, err)
+
+	archiveFile := filepath.Join(tmpDir, "orders-template.zip")
+	err = archive.Package(targetDir, archiveFile, archive.ZIPFormat)
+	assert.Nil(t, err)
+	generatedDir := filepath.Join(tmpDir, "generated")
+	archiver := &archive.ZIPArchiver{Processor: &archive.TemplateProcessor{}}
+	err = archiver.Extract(archiveFile, generatedDir, map[string]interface{}{"appName": "orders", "org": "acme"})
+
+	assert.Nil(t, err)
+	for name, content := range files {
+		assert.Equal(t, content, testhelper.ReadFile(t, filepath.Join(generatedDir, filepath.FromSlash(name))))
+	}
+}
+
+func TestExtractWithInvalidInput(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	projectDir := filepath.Join(tmpDir, "orders")
+	testhelper.WriteFiles(t, projectDir, map[string]string{"main.go": "package main"})
+	templateDir := filepath.Join(tmpDir, "template")
+	testhelper.WriteFiles(t, templateDir, map[string]string{"manifest.yaml": "version: \"1.0.0\""})
+
+	inputs := []struct {
+		name         string
+		replacements []*Replacement
+		projectDir   string
+		targetDir    string
+		expected     string
+	}{
+		{"no replacements", []*Replacement{}, projectDir, filepath.Join(tmpDir, "out"), "at least one literal needs to be replaced with a parameter"},
+		{"invalid parameter name", []*Replacement{{Parameter: "app-name", Literal: "orders"}}, projectDir, filepath.Join(tmpDir, "out"), "parameter name \"app-name\" may only contain letters, digits and underscores and may not start with a digit"},
+		{"empty literal", []*Replacement{{Parameter: "appName", Literal: ""}}, projectDir, filepath.Join(tmpDir, "out"), "parameter \"appName\" needs to replace a non-empty literal"},
+		{"literal with delimiters", []*Replacement{{Parameter: "appName", Literal: "{{ x }}"}}, projectDir, filepath.Join(tmpDir, "out"), "literal \"{{ x }}\" of parameter \"appName\" may not contain template delimiters"},
+		{"duplicate parameter", []*Replacement{{Parameter: "appName", Literal: "orders"}, {Parameter: "appName", Literal: "acme"}}, projectDir, filepath.Join(tmpDir, "out"), "parameter \"appName\" replaces more than one literal"},
+		{"duplicate literal", []*Replacement{{Parameter: "appName", Literal: "orders"}, {Parameter: "name", Literal: "orders"}}, projectDir, filepath.Join(tmpDir, "out"), "literal \"orders\" is replaced by more than one parameter"},
+		{"existing manifest", []*Replacement{{Parameter: "appName", Literal: "orders"}}, templateDir, filepath.Join(tmpDir, "out"), "project \"" + templateDir + "\" already contains a manifest.yaml file"},
+		{"target within project", []*Replacement{{Parameter: "appName", Literal: "orders"}}, projectDir, filepath.Join(projectDir, "template"), "template directory \"" + filepath.Join(projectDir, "template") + "\" may not be located within the project directory"},
+	}
+
+	for _, input := range inputs {
+		t.Run(input.name, func(t *testing.T) {
+			_, err := (&Extractor{Replacements: input.replacements}).Extract(input.projectDir, input.targetDir)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, input.expected, err.Error())
+		})
+	}
+}
+
+func TestParseReplacement(t *testing.T) {
+	r, err := ParseReplacement("module=github.com/acme/orders")
+	assert.Nil(t, err)
+	assert.Equal(t, &Replacement{Parameter: "module", Literal: "github.com/acme/orders"}, r)
+
+	_, err = ParseReplacement("module")
+	assert.NotNil(t, err)
+	assert.Equal(t, "replacement \"module\" needs to be defined as parameter=literal", err.Error())
+}
@@ -0,0 +1,66 @@
+package generate
+
+import (
+	"fmt"
+	"github.com/bmuschko/letsgopher/template/archive"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/bmuschko/letsgopher/template/gomod"
+)
+
+const (
+	// ModulePathParam is the implicit parameter holding the path of the enclosing module.
+	ModulePathParam = "modulePath"
+
+	// ModuleGoVersionParam is the implicit parameter holding the Go version declared by the enclosing module.
+	ModuleGoVersionParam = "moduleGoVersion"
+
+	// ImportPathParam is the implicit parameter holding the import path of the generated package.
+	ImportPathParam = "importPath"
+)
+
+// ModuleParams lists the implicit parameters describing the Go module a component is added to.
+var ModuleParams = []string{ModulePathParam, ModuleGoVersionParam, ImportPathParam}
+
+// PostProcessors determines the post-processors requested by the manifest of a template and the format option.
+// The archives are the archives of the template and the templates it extends, ordered from the extended to the extending template.
+func PostProcessors(archiver archive.Archiver, archives []string, m *config.ManifestFile, replacements map[string]interface{}, format bool) ([]archive.PostProcessor, error) {
+	var postProcessors []archive.PostProcessor
+	if m.GoModule {
+		rewriter, err := NewModuleRewriter(archiver, archives, m, replacements)
+		if err != nil {
+			return nil, err
+		}
+		postProcessors = append(postProcessors, rewriter)
+	}
+	if format || m.FormatGoFiles {
+		postProcessors = append(postProcessors, &archive.GoFormatter{})
+	}
+	return postProcessors, nil
+}
+
+// NewModuleRewriter rewrites the module path declared by the go.mod file of a template marked as Go module.
+// The go.mod file of an extending template takes precedence over the one of the template it extends.
+func NewModuleRewriter(archiver archive.Archiver, archives []string, m *config.ManifestFile, replacements map[string]interface{}) (*archive.ModuleRewriter, error) {
+	var b []byte
+	var err error
+	for i := len(archives) - 1; i >= 0; i-- {
+		b, err = archiver.LoadFile(archives[i], gomod.FileName)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("template is marked as Go module: %s", err)
+	}
+	f, err := gomod.Parse(b)
+	if err != nil {
+		return nil, err
+	}
+	name := m.ModuleParameterName()
+	modulePath, _ := replacements[name].(string)
+	err = config.CheckModulePath(modulePath)
+	if err != nil {
+		return nil, fmt.Errorf("module parameter %q does not provide a valid module path: %s", name, err)
+	}
+	return &archive.ModuleRewriter{From: f.Module, To: modulePath}, nil
+}
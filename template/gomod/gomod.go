@@ -0,0 +1,112 @@
+package gomod
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// FileName is the name of the file defining a Go module.
+const FileName = "go.mod"
+
+const (
+	moduleDirective = "module"
+	goDirective     = "go"
+	commentPrefix   = "//"
+)
+
+// File represents the directives of a go.mod file relevant for generating projects.
+type File struct {
+	Module string
+	Go     string
+}
+
+// Parse reads the module path and the Go version from the content of a go.mod file.
+func Parse(b []byte) (*File, error) {
+	f := &File{}
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		directive, value := splitDirective(s.Text())
+		switch directive {
+		case moduleDirective:
+			path, err := unquote(value)
+			if err != nil {
+				return nil, err
+			}
+			f.Module = path
+		case goDirective:
+			f.Go = value
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if f.Module == "" {
+		return nil, errors.New("go.mod file does not declare a module path")
+	}
+	return f, nil
+}
+
+// SetModulePath replaces the module path declared by the content of a go.mod file.
+// All other lines are left untouched.
+func SetModulePath(b []byte, path string) ([]byte, error) {
+	lines := strings.SplitAfter(string(b), "\n")
+	for i, l := range lines {
+		directive, _ := splitDirective(l)
+		if directive != moduleDirective {
+			continue
+		}
+		indent := l[:len(l)-len(strings.TrimLeft(l, " \t"))]
+		eol := l[len(strings.TrimRight(l, "\r\n")):]
+		lines[i] = indent + moduleDirective + " " + path + eol
+		return []byte(strings.Join(lines, "")), nil
+	}
+	return nil, errors.New("go.mod file does not declare a module path")
+}
+
+// Find looks up the go.mod file of the module enclosing a directory by walking up the directory hierarchy.
+// It returns the path of the go.mod file.
+func Find(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		f := filepath.Join(abs, FileName)
+		if info, err := os.Stat(f); err == nil && !info.IsDir() {
+			return f, nil
+		}
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return "", errors.New("could not locate go.mod file in directory " + dir + " or any of its parent directories")
+		}
+		abs = parent
+	}
+}
+
+// Matches determines whether an import path refers to a module path or one of its packages.
+func Matches(importPath string, modulePath string) bool {
+	return importPath == modulePath || strings.HasPrefix(importPath, modulePath+"/")
+}
+
+func splitDirective(line string) (string, string) {
+	if i := strings.Index(line, commentPrefix); i >= 0 {
+		line = line[:i]
+	}
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return "", ""
+	}
+	return fields[0], strings.Join(fields[1:], " ")
+}
+
+func unquote(s string) (string, error) {
+	if strings.HasPrefix(s, "\"") || strings.HasPrefix(s, "`") {
+		return strconv.Unquote(s)
+	}
+	return s, nil
+}
@@ -0,0 +1,71 @@
+package gomod
+
+import (
+	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/testhelper"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+const goMod = `// placeholder module of the template
+module github.com/example/placeholder // replaced on generation
+
+go 1.13
+
+require github.com/spf13/cobra v0.0.3
+`
+
+func TestParse(t *testing.T) {
+	f, err := Parse([]byte(goMod))
+
+	assert.Nil(t, err)
+	assert.Equal(t, &File{Module: "github.com/example/placeholder", Go: "1.13"}, f)
+}
+
+func TestParseQuotedModulePath(t *testing.T) {
+	f, err := Parse([]byte("module \"github.com/example/quoted\"\n"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "github.com/example/quoted", f.Module)
+}
+
+func TestParseWithoutModuleDirective(t *testing.T) {
+	f, err := Parse([]byte("go 1.13\n"))
+
+	assert.Nil(t, f)
+	assert.NotNil(t, err)
+	assert.Equal(t, "go.mod file does not declare a module path", err.Error())
+}
+
+func TestSetModulePath(t *testing.T) {
+	b, err := SetModulePath([]byte(goMod), "github.com/acme/hello")
+
+	assert.Nil(t, err)
+	assert.Equal(t, `// placeholder module of the template
+module github.com/acme/hello
+
+go 1.13
+
+require github.com/spf13/cobra v0.0.3
+`, string(b))
+}
+
+func TestMatches(t *testing.T) {
+	assert.True(t, Matches("github.com/example/placeholder", "github.com/example/placeholder"))
+	assert.True(t, Matches("github.com/example/placeholder/cmd", "github.com/example/placeholder"))
+	assert.False(t, Matches("github.com/example/placeholder2", "github.com/example/placeholder"))
+}
+
+func TestFindInParentDirectory(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	f := filepath.Join(tmpDir, FileName)
+	testhelper.WriteFile(t, f, goMod, 0644)
+	subDir := filepath.Join(tmpDir, "internal", "handler")
+	found, err := Find(subDir)
+
+	assert.Nil(t, err)
+	assert.Equal(t, f, found)
+}
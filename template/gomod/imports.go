@@ -0,0 +1,52 @@
+package gomod
+
+import (
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+)
+
+// RewriteImports replaces the module path of all imports in a Go source file referring to a module or one of its packages.
+// Only the import path literals are changed, the rest of the file is preserved as is.
+func RewriteImports(filename string, src []byte, from string, to string) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	type edit struct {
+		start int
+		end   int
+		path  string
+	}
+	edits := []edit{}
+	for _, i := range f.Imports {
+		path, err := strconv.Unquote(i.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		if !Matches(path, from) {
+			continue
+		}
+		edits = append(edits, edit{
+			start: fset.Position(i.Path.Pos()).Offset,
+			end:   fset.Position(i.Path.End()).Offset,
+			path:  to + path[len(from):],
+		})
+	}
+	if len(edits) == 0 {
+		return src, nil
+	}
+
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+	out := make([]byte, 0, len(src))
+	last := 0
+	for _, e := range edits {
+		out = append(out, src[last:e.start]...)
+		out = append(out, strconv.Quote(e.path)...)
+		last = e.end
+	}
+	return append(out, src[last:]...), nil
+}
@@ -0,0 +1,49 @@
+package gomod
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRewriteImports(t *testing.T) {
+	src := `package main
+
+import (
+	"fmt"
+
+	cmd "github.com/example/placeholder/cmd"
+	"github.com/example/placeholder2/util"
+)
+
+// github.com/example/placeholder/cmd stays untouched in comments
+func main() {
+	fmt.Println("github.com/example/placeholder")
+	cmd.Execute()
+}
+`
+	b, err := RewriteImports("main.go", []byte(src), "github.com/example/placeholder", "github.com/acme/hello")
+
+	assert.Nil(t, err)
+	assert.Equal(t, `package main
+
+import (
+	"fmt"
+
+	cmd "github.com/acme/hello/cmd"
+	"github.com/example/placeholder2/util"
+)
+
+// github.com/example/placeholder/cmd stays untouched in comments
+func main() {
+	fmt.Println("github.com/example/placeholder")
+	cmd.Execute()
+}
+`, string(b))
+}
+
+func TestRewriteImportsForInvalidSource(t *testing.T) {
+	b, err := RewriteImports("main.go", []byte("func main() {}"), "github.com/example/placeholder", "github.com/acme/hello")
+
+	assert.Nil(t, b)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,143 @@
+package hook
+
+import (
+	"errors"
+	"fmt"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/bmuschko/letsgopher/template/expression"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Command represents a hook rendered with parameter values and ready for execution.
+type Command struct {
+	Name    string
+	Args    []string
+	Dir     string
+	Message string
+}
+
+// String renders the command line of a command.
+func (c *Command) String() string {
+	return strings.Join(c.Args, " ")
+}
+
+// Verify evaluates the checks of all hooks whose condition is true for the given replacements.
+// The message of the first failing check is returned as error.
+func Verify(hooks []*config.Hook, replacements map[string]interface{}) error {
+	for _, h := range hooks {
+		if h.Check == "" {
+			continue
+		}
+		applicable, err := isApplicable(h, replacements)
+		if err != nil {
+			return err
+		}
+		if !applicable {
+			continue
+		}
+		ok, err := expression.EvaluateCondition(h.Check, replacements)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate check of hook %q: %s", describe(h), err)
+		}
+		if !ok {
+			if h.Message != "" {
+				return errors.New(h.Message)
+			}
+			return fmt.Errorf("check of hook %q failed", describe(h))
+		}
+	}
+	return nil
+}
+
+// Prepare renders the commands of all hooks whose condition is true for the given replacements.
+// Working directories are resolved relative to the project directory.
+func Prepare(hooks []*config.Hook, projectDir string, replacements map[string]interface{}) ([]*Command, error) {
+	commands := []*Command{}
+	for _, h := range hooks {
+		if len(h.Command) == 0 {
+			continue
+		}
+		applicable, err := isApplicable(h, replacements)
+		if err != nil {
+			return nil, err
+		}
+		if !applicable {
+			continue
+		}
+
+		args := []string{}
+		for _, arg := range h.Command {
+			a, err := expression.Render(arg, replacements)
+			if err != nil {
+				return nil, fmt.Errorf("failed to render command of hook %q: %s", describe(h), err)
+			}
+			args = append(args, a)
+		}
+		dir, err := expression.Render(h.WorkingDir, replacements)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render working directory of hook %q: %s", describe(h), err)
+		}
+		dir, err = resolveDir(projectDir, dir)
+		if err != nil {
+			return nil, fmt.Errorf("invalid working directory of hook %q: %s", describe(h), err)
+		}
+
+		name := h.Name
+		if name == "" {
+			name = strings.Join(args, " ")
+		}
+		commands = append(commands, &Command{Name: name, Args: args, Dir: dir, Message: h.Message})
+	}
+	return commands, nil
+}
+
+// Run executes commands in order and stops at the first failing command.
+// The message of the failing hook is returned as error, if declared.
+func Run(commands []*Command, executor Executor, out io.Writer) error {
+	for _, c := range commands {
+		fmt.Fprintf(out, "running hook %q\n", c.Name)
+		err := executor.Execute(c.Args, c.Dir)
+		if err != nil {
+			if c.Message != "" {
+				return errors.New(c.Message)
+			}
+			return fmt.Errorf("hook %q failed: %s", c.Name, err)
+		}
+	}
+	return nil
+}
+
+func isApplicable(h *config.Hook, replacements map[string]interface{}) (bool, error) {
+	if h.When == "" {
+		return true, nil
+	}
+	ok, err := expression.EvaluateCondition(h.When, replacements)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate condition of hook %q: %s", describe(h), err)
+	}
+	return ok, nil
+}
+
+func describe(h *config.Hook) string {
+	if h.Name != "" {
+		return h.Name
+	}
+	if h.Check != "" {
+		return h.Check
+	}
+	return strings.Join(h.Command, " ")
+}
+
+func resolveDir(projectDir string, dir string) (string, error) {
+	resolved := filepath.Join(projectDir, filepath.FromSlash(dir))
+	rel, err := filepath.Rel(projectDir, resolved)
+	if err != nil {
+		return "", err
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%q is outside of the project directory", dir)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,25 @@
+package hook
+
+import (
+	"errors"
+	"io"
+	"os/exec"
+)
+
+// CommandExecutor runs commands as child processes and streams their output.
+type CommandExecutor struct {
+	Out io.Writer
+	Err io.Writer
+}
+
+// Execute runs a command in a directory and waits for its completion.
+func (e *CommandExecutor) Execute(command []string, dir string) error {
+	if len(command) == 0 {
+		return errors.New("no command provided")
+	}
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Dir = dir
+	cmd.Stdout = e.Out
+	cmd.Stderr = e.Err
+	return cmd.Run()
+}
@@ -0,0 +1,128 @@
+package hook
+
+import (
+	"bytes"
+	"errors"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"path/filepath"
+	"testing"
+)
+
+func TestPrepareRendersApplicableHooks(t *testing.T) {
+	hooks := []*config.Hook{
+		{Command: []string{"go", "mod", "tidy"}},
+		{Name: "init repository", Command: []string{"git", "init"}, When: ".useGit"},
+		{Command: []string{"make", "generate"}, When: "not .useGit"},
+		{Command: []string{"go", "build", "./cmd/{{ .name }}"}, WorkingDir: "services/{{ .name }}"},
+	}
+	replacements := map[string]interface{}{"useGit": true, "name": "api"}
+	commands, err := Prepare(hooks, "/project", replacements)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*Command{
+		{Name: "go mod tidy", Args: []string{"go", "mod", "tidy"}, Dir: filepath.FromSlash("/project")},
+		{Name: "init repository", Args: []string{"git", "init"}, Dir: filepath.FromSlash("/project")},
+		{Name: "go build ./cmd/api", Args: []string{"go", "build", "./cmd/api"}, Dir: filepath.FromSlash("/project/services/api")},
+	}, commands)
+}
+
+func TestPrepareRejectsWorkingDirOutsideOfProject(t *testing.T) {
+	hooks := []*config.Hook{
+		{Command: []string{"rm", "-rf", "."}, WorkingDir: "{{ .dir }}"},
+	}
+	_, err := Prepare(hooks, "/project", map[string]interface{}{"dir": "../.."})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "invalid working directory of hook \"rm -rf .\": \"../..\" is outside of the project directory", err.Error())
+}
+
+func TestRunStopsAtFirstFailingCommand(t *testing.T) {
+	commands := []*Command{
+		{Name: "first", Args: []string{"first"}, Dir: "/project"},
+		{Name: "second", Args: []string{"second"}, Dir: "/project"},
+		{Name: "third", Args: []string{"third"}, Dir: "/project"},
+	}
+	b := bytes.NewBuffer(nil)
+	eM := new(ExecutorMock)
+	eM.On("Execute", []string{"first"}, "/project").Return(nil)
+	eM.On("Execute", []string{"second"}, "/project").Return(errors.New("exit status 1"))
+	err := Run(commands, eM, b)
+
+	eM.AssertExpectations(t)
+	assert.NotNil(t, err)
+	assert.Equal(t, "hook \"second\" failed: exit status 1", err.Error())
+	assert.Equal(t, "running hook \"first\"\nrunning hook \"second\"\n", b.String())
+}
+
+func TestCommandExecutorStreamsOutput(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	executor := &CommandExecutor{Out: b, Err: b}
+	err := executor.Execute([]string{"go", "version"}, ".")
+
+	assert.Nil(t, err)
+	assert.Contains(t, b.String(), "go version")
+}
+
+type ExecutorMock struct {
+	mock.Mock
+}
+
+func (e *ExecutorMock) Execute(command []string, dir string) error {
+	args := e.Called(command, dir)
+	return args.Error(0)
+}
+
+func TestVerifyPassingChecks(t *testing.T) {
+	hooks := []*config.Hook{
+		{Check: `semverCompare ">=1.11" .goVersion`},
+		{Check: `ne .name ""`},
+		{Command: []string{"ignored"}},
+	}
+	err := Verify(hooks, map[string]interface{}{"goVersion": "1.12.1", "name": "api"})
+
+	assert.Nil(t, err)
+}
+
+func TestVerifyFailingCheckWithMessage(t *testing.T) {
+	hooks := []*config.Hook{
+		{Check: `semverCompare ">=1.21" .goVersion`, Message: "Go 1.21 or higher needs to be installed"},
+	}
+	err := Verify(hooks, map[string]interface{}{"goVersion": "1.12.1"})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "Go 1.21 or higher needs to be installed", err.Error())
+}
+
+func TestVerifyFailingCheckWithoutMessage(t *testing.T) {
+	hooks := []*config.Hook{
+		{Check: `.useDatabase`},
+	}
+	err := Verify(hooks, map[string]interface{}{})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "check of hook \".useDatabase\" failed", err.Error())
+}
+
+func TestVerifySkipsChecksWithFalseCondition(t *testing.T) {
+	hooks := []*config.Hook{
+		{Check: `.database`, When: ".useDatabase"},
+	}
+	err := Verify(hooks, map[string]interface{}{"useDatabase": false})
+
+	assert.Nil(t, err)
+}
+
+func TestRunReturnsMessageOfFailingCommand(t *testing.T) {
+	commands := []*Command{
+		{Name: "git", Args: []string{"git", "rev-parse"}, Dir: "/project", Message: "the project needs to be located in a git repository"},
+	}
+	eM := new(ExecutorMock)
+	eM.On("Execute", []string{"git", "rev-parse"}, "/project").Return(errors.New("exit status 128"))
+	err := Run(commands, eM, bytes.NewBuffer(nil))
+
+	eM.AssertExpectations(t)
+	assert.NotNil(t, err)
+	assert.Equal(t, "the project needs to be located in a git repository", err.Error())
+}
@@ -0,0 +1,6 @@
+package hook
+
+// Executor runs a command in a directory.
+type Executor interface {
+	Execute(command []string, dir string) error
+}
@@ -0,0 +1,59 @@
+package lint
+
+// Severity classifies how severe a finding is.
+type Severity string
+
+const (
+	// Error marks findings which break the generation of projects from the template.
+	Error Severity = "error"
+
+	// Warning marks findings which are likely mistakes of the template author.
+	Warning Severity = "warning"
+)
+
+// Rule describes a check performed on the files of a template.
+type Rule struct {
+	ID          string
+	Severity    Severity
+	Description string
+}
+
+var (
+	// ManifestRule checks the manifest file against the manifest schema and its validation rules.
+	ManifestRule = &Rule{ID: "manifest", Severity: Error, Description: "The manifest file needs to be valid."}
+
+	// ParseRule checks that files and partials can be parsed as Go templates.
+	ParseRule = &Rule{ID: "template-syntax", Severity: Error, Description: "Template files need to be valid Go templates."}
+
+	// UndeclaredParameterRule checks that template files only refer to parameters declared in the manifest.
+	UndeclaredParameterRule = &Rule{ID: "undeclared-parameter", Severity: Error, Description: "Template files may only refer to parameters declared in the manifest."}
+
+	// UndefinedPartialRule checks that template files and partials only invoke partials which are defined.
+	UndefinedPartialRule = &Rule{ID: "undefined-partial", Severity: Error, Description: "Template files may only invoke partials which are defined."}
+
+	// UnusedParameterRule checks that every declared parameter is used by a template file or the manifest.
+	UnusedParameterRule = &Rule{ID: "unused-parameter", Severity: Warning, Description: "Parameters declared in the manifest should be used."}
+
+	// UnreachableFileRule checks that files wrapped in a condition can be generated with content.
+	UnreachableFileRule = &Rule{ID: "unreachable-file", Severity: Warning, Description: "Files wrapped in a condition need a condition which can be true."}
+
+	// BinaryFileRule checks for binary files which would be rendered as templates.
+	BinaryFileRule = &Rule{ID: "binary-file", Severity: Warning, Description: "Binary files are rendered as templates and may be corrupted."}
+
+	// ComponentParameterRule checks for template files referring to parameters only provided when adding a component without declaring them.
+	ComponentParameterRule = &Rule{ID: "component-parameter", Severity: Warning, Description: "Parameters only provided by the add command render empty when creating a project."}
+
+	// PathTraversalRule checks for archive entries which would be written outside of the project directory.
+	PathTraversalRule = &Rule{ID: "path-traversal", Severity: Error, Description: "Files may not be written outside of the project directory."}
+)
+
+// Rules lists all rules checked by the linter.
+var Rules = []*Rule{ManifestRule, ParseRule, UndeclaredParameterRule, UnusedParameterRule, UnreachableFileRule, BinaryFileRule, PathTraversalRule, UndefinedPartialRule, ComponentParameterRule}
+
+// Finding is a violation of a rule. The line is 0 if the finding applies to the file as a whole.
+type Finding struct {
+	Rule    *Rule
+	File    string
+	Line    int
+	Message string
+}
@@ -0,0 +1,398 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/bmuschko/letsgopher/template/archive"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/bmuschko/letsgopher/template/expression"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+const (
+	partialsDir = "_partials"
+)
+
+// Linter checks the files of a template for mistakes of template authors.
+type Linter struct {
+	// Implicit lists the parameters provided by letsgopher which template files can use without declaring them.
+	Implicit []string
+
+	// ComponentParams lists the parameters only provided when a template is added as a component.
+	// Template files referring to them without declaring them are reported as warning, as they render empty when creating a project.
+	ComponentParams []string
+}
+
+// Lint checks the files of a template and returns the findings ordered by file and line.
+// References to parameters and invocations of partials are only checked for templates which don't extend another template,
+// as the parameters and partials of the extended template are unknown.
+func (l *Linter) Lint(files []*File) []*Finding {
+	r := &run{linter: l, partials: make(map[string]*template.Template), partialFiles: make(map[string]string)}
+	for _, f := range files {
+		r.checkPath(f)
+	}
+	r.checkManifest(files)
+
+	rendered := []*File{}
+	for _, f := range files {
+		name := path.Clean(f.Name)
+		switch {
+		case path.Base(name) == config.ManifestFileName || name == partialsDir:
+			continue
+		case strings.HasPrefix(name, partialsDir+"/"):
+			if !f.Dir {
+				r.parsePartial(f)
+			}
+		case f.Dir:
+			r.checkName(f)
+		default:
+			r.checkName(f)
+			rendered = append(rendered, f)
+		}
+	}
+	for _, f := range rendered {
+		r.checkFile(f)
+	}
+	for _, name := range sortedKeys(r.partials) {
+		r.walk(r.partialFiles[name], r.partials[name])
+	}
+	r.checkReferences()
+
+	sort.SliceStable(r.findings, func(i, j int) bool {
+		if r.findings[i].File != r.findings[j].File {
+			return r.findings[i].File < r.findings[j].File
+		}
+		return r.findings[i].Line < r.findings[j].Line
+	})
+	return r.findings
+}
+
+// HasErrors determines whether any of the findings has the severity error.
+func HasErrors(findings []*Finding) bool {
+	return Count(findings, Error) > 0
+}
+
+// Count determines the number of findings with a severity.
+func Count(findings []*Finding, severity Severity) int {
+	count := 0
+	for _, f := range findings {
+		if f.Rule.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// run holds the state of linting the files of a single template.
+type run struct {
+	linter       *Linter
+	manifest     *config.ManifestFile
+	partials     map[string]*template.Template
+	partialFiles map[string]string
+	references   []*reference
+	findings     []*Finding
+}
+
+func (r *run) report(rule *Rule, file string, line int, format string, args ...interface{}) {
+	r.findings = append(r.findings, &Finding{Rule: rule, File: file, Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+func (r *run) checkPath(f *File) {
+	name := strings.Replace(f.Name, `\`, "/", -1)
+	clean := path.Clean(name)
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") || hasVolumeName(clean) {
+		r.report(PathTraversalRule, f.Name, 0, "entry %q would be written outside of the project directory", f.Name)
+	}
+}
+
+func hasVolumeName(name string) bool {
+	return len(name) >= 2 && name[1] == ':' && (name[0] >= 'a' && name[0] <= 'z' || name[0] >= 'A' && name[0] <= 'Z')
+}
+
+func (r *run) checkManifest(files []*File) {
+	var f *File
+	for _, candidate := range files {
+		if !candidate.Dir && path.Clean(candidate.Name) == config.ManifestFileName {
+			f = candidate
+			break
+		}
+	}
+	if f == nil {
+		r.report(ManifestRule, config.ManifestFileName, 0, "template needs to provide a %s file", config.ManifestFileName)
+		return
+	}
+
+	errs, err := config.CheckSchema(f.Content)
+	if err != nil {
+		r.report(ManifestRule, f.Name, 0, "failed to parse manifest: %s", err)
+		return
+	}
+	for _, e := range errs {
+		r.report(ManifestRule, f.Name, e.Line, "%s", &config.ManifestError{Path: e.Path, Err: e.Err})
+	}
+	m, err := config.LoadManifestData(f.Content)
+	if err != nil {
+		return
+	}
+	if len(errs) == 0 {
+		err = config.ValidateManifest(m)
+		if me, ok := err.(*config.ManifestError); ok {
+			r.report(ManifestRule, f.Name, me.Line, "%s", &config.ManifestError{Path: me.Path, Err: me.Err})
+		} else if err != nil {
+			r.report(ManifestRule, f.Name, 0, "%s", err)
+		}
+	}
+	r.manifest = m
+}
+
+func (r *run) parsePartial(f *File) {
+	if archive.IsBinary(f.Content) {
+		return
+	}
+	name := strings.TrimPrefix(path.Clean(f.Name), partialsDir+"/")
+	name = strings.TrimSuffix(name, path.Ext(name))
+	t, err := template.New(name).Funcs(expression.FuncMap()).Parse(string(f.Content))
+	if err != nil {
+		r.report(ParseRule, f.Name, errorLine(err, name), "%s", err)
+		return
+	}
+	r.partials[name] = t
+	r.partialFiles[name] = f.Name
+}
+
+// checkName checks the placeholders of a file path, e.g. cmd/{{ .appName }}/main.go.
+func (r *run) checkName(f *File) {
+	if !strings.Contains(f.Name, "{{") {
+		return
+	}
+	t, err := template.New(f.Name).Funcs(expression.FuncMap()).Parse(f.Name)
+	if err != nil {
+		r.report(ParseRule, f.Name, 0, "%s", err)
+		return
+	}
+	w := &walker{file: f.Name, tree: t.Tree}
+	w.walk(t.Tree.Root, true)
+	for _, ref := range w.references {
+		ref.line = 0
+	}
+	r.references = append(r.references, w.references...)
+}
+
+func (r *run) checkFile(f *File) {
+	if archive.IsBinary(f.Content) {
+		r.report(BinaryFileRule, f.Name, 0, "binary file would be rendered as template and may be corrupted")
+		return
+	}
+	t := template.New(f.Name).Funcs(expression.FuncMap())
+	for _, name := range sortedKeys(r.partials) {
+		_, err := t.AddParseTree(name, r.partials[name].Tree)
+		if err != nil {
+			return
+		}
+	}
+	t, err := t.Parse(string(f.Content))
+	if err != nil {
+		r.report(ParseRule, f.Name, errorLine(err, f.Name), "%s", err)
+		return
+	}
+	r.walk(f.Name, t)
+	r.checkReachable(f.Name, t.Tree)
+}
+
+// checkReachable reports files whose content is entirely wrapped in a condition which can never be true.
+// Such files are always generated without content.
+func (r *run) checkReachable(file string, tree *parse.Tree) {
+	var condition *parse.IfNode
+	for _, n := range tree.Root.Nodes {
+		if t, ok := n.(*parse.TextNode); ok && len(bytes.TrimSpace(t.Text)) == 0 {
+			continue
+		}
+		ifNode, ok := n.(*parse.IfNode)
+		if !ok || condition != nil || ifNode.ElseList != nil {
+			return
+		}
+		condition = ifNode
+	}
+	if condition != nil && r.neverTrue(condition.Pipe) {
+		r.report(UnreachableFileRule, file, nodeLine(tree, condition), "file is wrapped in the condition %q which can never be true", condition.Pipe)
+	}
+}
+
+func (r *run) neverTrue(pipe *parse.PipeNode) bool {
+	if len(pipe.Decl) > 0 || len(pipe.Cmds) != 1 {
+		return false
+	}
+	args := pipe.Cmds[0].Args
+	if len(args) == 1 {
+		switch n := args[0].(type) {
+		case *parse.BoolNode:
+			return !n.True
+		case *parse.FieldNode:
+			return r.manifest != nil && r.manifest.Extends == nil && len(n.Ident) == 1 && !r.declared(n.Ident[0]) && !r.componentParam(n.Ident[0])
+		}
+		return false
+	}
+	if f, ok := args[0].(*parse.IdentifierNode); !ok || f.Ident != "eq" || len(args) != 3 {
+		return false
+	}
+	field, value := args[1], args[2]
+	if _, ok := field.(*parse.StringNode); ok {
+		field, value = value, field
+	}
+	f, ok := field.(*parse.FieldNode)
+	s, isString := value.(*parse.StringNode)
+	if !ok || !isString || len(f.Ident) != 1 {
+		return false
+	}
+	p := r.parameter(f.Ident[0])
+	if p == nil || p.Enum == nil {
+		return false
+	}
+	for _, e := range p.Enum {
+		if e == s.Text {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *run) parameter(name string) *config.Parameter {
+	if r.manifest == nil {
+		return nil
+	}
+	for _, p := range r.manifest.Parameters {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func (r *run) declared(name string) bool {
+	if r.parameter(name) != nil {
+		return true
+	}
+	return contains(r.linter.Implicit, name)
+}
+
+func (r *run) componentParam(name string) bool {
+	return contains(r.linter.ComponentParams, name)
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkReferences reports references to undeclared parameters and declared parameters which are never used.
+func (r *run) checkReferences() {
+	if r.manifest == nil || r.manifest.Extends != nil {
+		return
+	}
+	used := make(map[string]bool)
+	for _, ref := range r.references {
+		used[ref.name] = true
+		if r.componentParam(ref.name) && !r.declared(ref.name) {
+			r.report(ComponentParameterRule, ref.file, ref.line, "parameter %q is only provided by the add command and renders empty when creating a project", ref.name)
+		} else if !r.declared(ref.name) {
+			r.report(UndeclaredParameterRule, ref.file, ref.line, "parameter %q is not declared in the manifest", ref.name)
+		}
+	}
+	for _, name := range r.manifestReferences() {
+		used[name] = true
+	}
+	if r.manifest.GoModule {
+		used[r.manifest.ModuleParameter] = true
+	}
+	for i, p := range r.manifest.Parameters {
+		if !used[p.Name] {
+			r.report(UnusedParameterRule, config.ManifestFileName, r.manifest.Line(fmt.Sprintf("/parameters/%d", i)), "parameter %q is never used", p.Name)
+		}
+	}
+}
+
+// manifestReferences determines the parameters used by expressions of the manifest itself, e.g. conditions and hook commands.
+func (r *run) manifestReferences() []string {
+	m := r.manifest
+	expressions := []string{}
+	for _, p := range m.Parameters {
+		expressions = append(expressions, p.When, p.Computed)
+		if expression.IsTemplate(p.DefaultValue) {
+			expressions = append(expressions, p.DefaultValue)
+		}
+	}
+	for _, h := range append(append([]*config.Hook{}, m.Hooks.PreGenerate...), m.Hooks.PostGenerate...) {
+		expressions = append(expressions, h.When, h.Check, h.WorkingDir)
+		expressions = append(expressions, h.Command...)
+	}
+	for _, d := range m.Dependencies {
+		for _, v := range d.Parameters {
+			expressions = append(expressions, v)
+		}
+	}
+
+	names := []string{}
+	for _, e := range expressions {
+		if strings.TrimSpace(e) == "" {
+			continue
+		}
+		tree, err := expression.Tree(e)
+		if err != nil {
+			continue
+		}
+		w := &walker{tree: tree}
+		w.walk(tree.Root, true)
+		for _, ref := range w.references {
+			names = append(names, ref.name)
+		}
+	}
+	return names
+}
+
+// walk collects the parameters referenced by a template and reports invocations of partials which aren't defined.
+// Templates defined by the file itself count as defined. Partials of templates extending another template may be provided by the extended template.
+func (r *run) walk(file string, t *template.Template) {
+	w := &walker{file: file, tree: t.Tree}
+	w.walk(t.Tree.Root, true)
+	r.references = append(r.references, w.references...)
+	if r.manifest != nil && r.manifest.Extends != nil {
+		return
+	}
+	for _, inv := range w.invocations {
+		if t.Lookup(inv.name) == nil && r.partials[inv.name] == nil {
+			r.report(UndefinedPartialRule, inv.file, inv.line, "partial %q is not defined", inv.name)
+		}
+	}
+}
+
+// errorLine determines the line reported by a parse error of a template with the given name.
+func errorLine(err error, name string) int {
+	prefix := "template: " + name + ":"
+	msg := err.Error()
+	if !strings.HasPrefix(msg, prefix) {
+		return 0
+	}
+	line, err := strconv.Atoi(strings.SplitN(strings.TrimPrefix(msg, prefix), ":", 2)[0])
+	if err != nil {
+		return 0
+	}
+	return line
+}
+
+func sortedKeys(m map[string]*template.Template) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
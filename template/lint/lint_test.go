@@ -0,0 +1,181 @@
+package lint
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const lintManifest = `version: "1.0.0"
+parameters:
+  - name: "name"
+    type: "string"
+  - name: "database"
+    type: "string"
+    enum: ["postgres", "mysql"]
+  - name: "license"
+    type: "string"
+  - name: "year"
+    type: "string"
+    computed: "{{ .name }}"
+hooks:
+  postGenerate:
+    - command: ["git", "init", "{{ .name }}"]
+`
+
+func TestLintValidTemplate(t *testing.T) {
+	files := []*File{
+		{Name: "manifest.yaml", Content: []byte(lintManifest)},
+		{Name: "_partials", Dir: true},
+		{Name: "_partials/header.tmpl", Content: []byte("// {{ .license }}")},
+		{Name: "main.go", Content: []byte("{{ template \"header\" . }}\npackage {{ .name }}\n\n{{ range .list }}{{ .item }}{{ $.year }}{{ end }}")},
+		{Name: "db.sql", Content: []byte(`{{ if eq .database "postgres" }}CREATE TABLE t;{{ end }}`)},
+		{Name: "go.mod", Content: []byte("module {{ .modulePath }}")},
+	}
+	findings := (&Linter{Implicit: []string{"modulePath"}}).Lint(files)
+
+	assert.Equal(t, []*Finding{{Rule: UndeclaredParameterRule, File: "main.go", Line: 4, Message: "parameter \"list\" is not declared in the manifest"}}, findings)
+}
+
+func TestLintReportsUndeclaredComponentParamsAsWarning(t *testing.T) {
+	files := []*File{
+		{Name: "manifest.yaml", Content: []byte("version: \"1.0.0\"\nparameters:\n  - name: \"importPath\"\n    type: \"string\"\n")},
+		{Name: "go.mod", Content: []byte("module {{ .modulePath }}")},
+		{Name: "doc.go", Content: []byte("// Package {{ .importPath }}\npackage main")},
+		{Name: "version.txt", Content: []byte("{{ if .moduleGoVersion }}go {{ .moduleGoVersion }}{{ end }}")},
+		{Name: "main.go", Content: []byte("package {{ .nmae }}")},
+	}
+	findings := (&Linter{ComponentParams: []string{"modulePath", "moduleGoVersion", "importPath"}}).Lint(files)
+
+	assert.Equal(t, []*Finding{
+		{Rule: ComponentParameterRule, File: "go.mod", Line: 1, Message: "parameter \"modulePath\" is only provided by the add command and renders empty when creating a project"},
+		{Rule: UndeclaredParameterRule, File: "main.go", Line: 1, Message: "parameter \"nmae\" is not declared in the manifest"},
+		{Rule: ComponentParameterRule, File: "version.txt", Line: 1, Message: "parameter \"moduleGoVersion\" is only provided by the add command and renders empty when creating a project"},
+		{Rule: ComponentParameterRule, File: "version.txt", Line: 1, Message: "parameter \"moduleGoVersion\" is only provided by the add command and renders empty when creating a project"},
+	}, findings)
+}
+
+func TestLintReportsProblems(t *testing.T) {
+	files := []*File{
+		{Name: "manifest.yaml", Content: []byte(lintManifest)},
+		{Name: "main.go", Content: []byte("package main\n\n// {{ .year }}\nfunc main() {\n\t{{ .nmae }}\n}")},
+		{Name: "broken.go", Content: []byte("package main\n\n{{ if .name }}")},
+		{Name: "mysql.sql", Content: []byte("\n{{ if eq .database \"oracle\" }}\nCREATE TABLE t;\n{{ end }}\n")},
+		{Name: "logo.png", Content: []byte{0x89, 'P', 'N', 'G', 0x00, '{', '{'}},
+		{Name: "../../etc/passwd", Content: []byte("root")},
+	}
+	findings := (&Linter{}).Lint(files)
+
+	assert.Equal(t, []*Finding{
+		{Rule: PathTraversalRule, File: "../../etc/passwd", Message: "entry \"../../etc/passwd\" would be written outside of the project directory"},
+		{Rule: ParseRule, File: "broken.go", Line: 3, Message: "template: broken.go:3: unexpected EOF"},
+		{Rule: BinaryFileRule, File: "logo.png", Message: "binary file would be rendered as template and may be corrupted"},
+		{Rule: UndeclaredParameterRule, File: "main.go", Line: 5, Message: "parameter \"nmae\" is not declared in the manifest"},
+		{Rule: UnusedParameterRule, File: "manifest.yaml", Line: 8, Message: "parameter \"license\" is never used"},
+		{Rule: UnreachableFileRule, File: "mysql.sql", Line: 2, Message: "file is wrapped in the condition \"eq .database \\\"oracle\\\"\" which can never be true"},
+	}, findings)
+	assert.True(t, HasErrors(findings))
+	assert.Equal(t, 3, Count(findings, Warning))
+}
+
+func TestLintInvalidManifest(t *testing.T) {
+	manifests := []struct {
+		name     string
+		files    []*File
+		expected []*Finding
+	}{
+		{
+			"missing manifest",
+			[]*File{},
+			[]*Finding{{Rule: ManifestRule, File: "manifest.yaml", Message: "template needs to provide a manifest.yaml file"}},
+		},
+		{
+			"schema violations",
+			[]*File{{Name: "manifest.yaml", Content: []byte("version: \"1.0.0\"\nparameters:\n  - name: \"p\"\n    type: \"float\"\n    optional: true\n")}},
+			[]*Finding{
+				{Rule: UnusedParameterRule, File: "manifest.yaml", Line: 3, Message: "parameter \"p\" is never used"},
+				{Rule: ManifestRule, File: "manifest.yaml", Line: 4, Message: "/parameters/0/type: needs to be one of string, integer, boolean, list, map, path, url, email, semver, modulePath"},
+				{Rule: ManifestRule, File: "manifest.yaml", Line: 5, Message: "/parameters/0/optional: unknown property \"optional\""},
+			},
+		},
+		{
+			"validation error",
+			[]*File{{Name: "manifest.yaml", Content: []byte("version: \"1.0.0\"\nparameters:\n  - name: \"p\"\n    type: \"integer\"\n    defaultValue: \"abc\"\n")}},
+			[]*Finding{
+				{Rule: UnusedParameterRule, File: "manifest.yaml", Line: 3, Message: "parameter \"p\" is never used"},
+				{Rule: ManifestRule, File: "manifest.yaml", Line: 5, Message: "/parameters/0/defaultValue: strconv.Atoi: parsing \"abc\": invalid syntax"},
+			},
+		},
+	}
+
+	for _, m := range manifests {
+		t.Run(m.name, func(t *testing.T) {
+			findings := (&Linter{}).Lint(m.files)
+
+			assert.Equal(t, m.expected, findings)
+		})
+	}
+}
+
+func TestLintAcceptsScalarParameterValues(t *testing.T) {
+	files := []*File{
+		{Name: "manifest.yaml", Content: []byte(`version: "1.0.0"
+parameters:
+  - name: "port"
+    type: "integer"
+    defaultValue: 8080
+    enum: [8080, 9090]
+  - name: "debug"
+    type: "boolean"
+    defaultValue: true
+  - name: "tracing"
+    type: "boolean"
+    when: "{{ .debug }}"
+    fallback: false
+`)},
+		{Name: "main.go", Content: []byte("// {{ .port }} {{ .debug }} {{ .tracing }}")},
+	}
+	findings := (&Linter{}).Lint(files)
+
+	assert.Empty(t, findings)
+}
+
+func TestLintChecksPlaceholdersInPaths(t *testing.T) {
+	files := []*File{
+		{Name: "manifest.yaml", Content: []byte("version: \"1.0.0\"\nparameters:\n  - name: \"appName\"\n    type: \"string\"\n")},
+		{Name: "cmd/{{ .appName }}", Dir: true},
+		{Name: "cmd/{{ .appName }}/main.go", Content: []byte("package main")},
+		{Name: "{{ .nmae }}.yaml", Content: []byte("name: test")},
+		{Name: "{{ .appName", Content: []byte("")},
+	}
+	findings := (&Linter{}).Lint(files)
+
+	assert.Equal(t, []*Finding{
+		{Rule: ParseRule, File: "{{ .appName", Message: "template: {{ .appName:1: unclosed action"},
+		{Rule: UndeclaredParameterRule, File: "{{ .nmae }}.yaml", Message: "parameter \"nmae\" is not declared in the manifest"},
+	}, findings)
+}
+
+func TestLintReportsUndefinedPartials(t *testing.T) {
+	files := []*File{
+		{Name: "manifest.yaml", Content: []byte("version: \"1.0.0\"\n")},
+		{Name: "_partials/header.tmpl", Content: []byte("// {{ template \"license\" . }}")},
+		{Name: "main.go", Content: []byte("{{ template \"header\" . }}\n{{ template \"footer\" . }}\npackage {{ template \"pkg\" . }}\n{{ define \"pkg\" }}main{{ end }}")},
+	}
+	findings := (&Linter{}).Lint(files)
+
+	assert.Equal(t, []*Finding{
+		{Rule: UndefinedPartialRule, File: "_partials/header.tmpl", Line: 1, Message: "partial \"license\" is not defined"},
+		{Rule: UndefinedPartialRule, File: "main.go", Line: 2, Message: "partial \"footer\" is not defined"},
+	}, findings)
+	assert.True(t, HasErrors(findings))
+}
+
+func TestLintSkipsReferencesOfExtendingTemplate(t *testing.T) {
+	files := []*File{
+		{Name: "manifest.yaml", Content: []byte("version: \"1.0.0\"\nextends:\n  name: \"base\"\n  version: \"1.0.0\"\n")},
+		{Name: "main.go", Content: []byte("{{ template \"header\" . }}\npackage {{ .name }}")},
+	}
+	findings := (&Linter{}).Lint(files)
+
+	assert.Empty(t, findings)
+}
@@ -0,0 +1,91 @@
+package lint
+
+import (
+	"strconv"
+	"strings"
+	"text/template/parse"
+)
+
+// reference is the use of a parameter in a template file.
+type reference struct {
+	name string
+	file string
+	line int
+}
+
+// walker collects the parameters referenced and the partials invoked by a parse tree.
+// Fields are only parameters as long as the dot refers to the parameter values, i.e. outside of the bodies of with and range actions.
+type walker struct {
+	file        string
+	tree        *parse.Tree
+	references  []*reference
+	invocations []*reference
+}
+
+func (w *walker) walk(node parse.Node, root bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			w.walk(c, root)
+		}
+	case *parse.ActionNode:
+		w.walk(n.Pipe, root)
+	case *parse.IfNode:
+		w.branch(&n.BranchNode, root, root)
+	case *parse.WithNode:
+		w.branch(&n.BranchNode, root, false)
+	case *parse.RangeNode:
+		w.branch(&n.BranchNode, root, false)
+	case *parse.TemplateNode:
+		w.invocations = append(w.invocations, &reference{name: n.Name, file: w.file, line: nodeLine(w.tree, n)})
+		w.walk(n.Pipe, root)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Cmds {
+			w.walk(c, root)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			w.walk(arg, root)
+		}
+	case *parse.ChainNode:
+		w.walk(n.Node, root)
+	case *parse.FieldNode:
+		if root {
+			w.add(n, n.Ident[0])
+		}
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			w.add(n, n.Ident[1])
+		}
+	}
+}
+
+func (w *walker) branch(b *parse.BranchNode, root bool, bodyRoot bool) {
+	w.walk(b.Pipe, root)
+	w.walk(b.List, root && bodyRoot)
+	w.walk(b.ElseList, root)
+}
+
+func (w *walker) add(n parse.Node, name string) {
+	w.references = append(w.references, &reference{name: name, file: w.file, line: nodeLine(w.tree, n)})
+}
+
+// nodeLine determines the line of a node within the parsed text.
+func nodeLine(tree *parse.Tree, n parse.Node) int {
+	location, _ := tree.ErrorContext(n)
+	parts := strings.Split(location, ":")
+	if len(parts) < 3 {
+		return 0
+	}
+	line, err := strconv.Atoi(parts[len(parts)-2])
+	if err != nil {
+		return 0
+	}
+	return line
+}
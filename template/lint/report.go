@@ -0,0 +1,152 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "letsgopher"
+	toolURI      = "https://github.com/bmuschko/letsgopher"
+)
+
+// WriteText renders findings for humans, one finding per line followed by a summary.
+func WriteText(w io.Writer, findings []*Finding) error {
+	for _, f := range findings {
+		location := f.File
+		if f.Line > 0 {
+			location = fmt.Sprintf("%s:%d", f.File, f.Line)
+		}
+		_, err := fmt.Fprintf(w, "%s: %s: %s [%s]\n", location, f.Rule.Severity, f.Message, f.Rule.ID)
+		if err != nil {
+			return err
+		}
+	}
+	if len(findings) == 0 {
+		_, err := fmt.Fprintln(w, "no problems found")
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%d problem(s): %d error(s), %d warning(s)\n", len(findings), Count(findings, Error), Count(findings, Warning))
+	return err
+}
+
+type jsonReport struct {
+	Findings []*jsonFinding `json:"findings"`
+}
+
+type jsonFinding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	File     string   `json:"file"`
+	Line     int      `json:"line,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// WriteJSON renders findings as JSON document.
+func WriteJSON(w io.Writer, findings []*Finding) error {
+	report := &jsonReport{Findings: []*jsonFinding{}}
+	for _, f := range findings {
+		report.Findings = append(report.Findings, &jsonFinding{Rule: f.Rule.ID, Severity: f.Rule.Severity, File: f.File, Line: f.Line, Message: f.Message})
+	}
+	return writeIndented(w, report)
+}
+
+type sarifLog struct {
+	Version string      `json:"version"`
+	Schema  string      `json:"$schema"`
+	Runs    []*sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool      `json:"tool"`
+	Results []*sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string       `json:"name"`
+	Version        string       `json:"version,omitempty"`
+	InformationURI string       `json:"informationUri"`
+	Rules          []*sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level Severity `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string           `json:"ruleId"`
+	RuleIndex int              `json:"ruleIndex"`
+	Level     Severity         `json:"level"`
+	Message   sarifMessage     `json:"message"`
+	Locations []*sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// WriteSARIF renders findings in the Static Analysis Results Interchange Format (SARIF) 2.1.0 understood by many CI systems.
+// The version identifies the running letsgopher version and may be empty.
+func WriteSARIF(w io.Writer, findings []*Finding, version string) error {
+	driver := sarifDriver{Name: toolName, Version: version, InformationURI: toolURI, Rules: []*sarifRule{}}
+	ruleIndex := make(map[*Rule]int)
+	for i, r := range Rules {
+		ruleIndex[r] = i
+		driver.Rules = append(driver.Rules, &sarifRule{ID: r.ID, ShortDescription: sarifMessage{Text: r.Description}, DefaultConfiguration: sarifConfiguration{Level: r.Severity}})
+	}
+
+	run := &sarifRun{Tool: sarifTool{Driver: driver}, Results: []*sarifResult{}}
+	for _, f := range findings {
+		location := &sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: f.File}}}
+		if f.Line > 0 {
+			location.PhysicalLocation.Region = &sarifRegion{StartLine: f.Line}
+		}
+		run.Results = append(run.Results, &sarifResult{
+			RuleID:    f.Rule.ID,
+			RuleIndex: ruleIndex[f.Rule],
+			Level:     f.Rule.Severity,
+			Message:   sarifMessage{Text: f.Message},
+			Locations: []*sarifLocation{location},
+		})
+	}
+	return writeIndented(w, &sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []*sarifRun{run}})
+}
+
+func writeIndented(w io.Writer, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(b))
+	return err
+}
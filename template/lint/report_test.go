@@ -0,0 +1,80 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var reportFindings = []*Finding{
+	{Rule: ParseRule, File: "main.go", Line: 3, Message: "template: main.go:3: unexpected EOF"},
+	{Rule: BinaryFileRule, File: "logo.png", Message: "binary file would be rendered as template and may be corrupted"},
+}
+
+func TestWriteText(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	err := WriteText(b, reportFindings)
+
+	assert.Nil(t, err)
+	assert.Equal(t, `main.go:3: error: template: main.go:3: unexpected EOF [template-syntax]
+logo.png: warning: binary file would be rendered as template and may be corrupted [binary-file]
+2 problem(s): 1 error(s), 1 warning(s)
+`, b.String())
+}
+
+func TestWriteTextWithoutFindings(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	err := WriteText(b, []*Finding{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "no problems found\n", b.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	err := WriteJSON(b, reportFindings)
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"findings": [
+  {"rule": "template-syntax", "severity": "error", "file": "main.go", "line": 3, "message": "template: main.go:3: unexpected EOF"},
+  {"rule": "binary-file", "severity": "warning", "file": "logo.png", "message": "binary file would be rendered as template and may be corrupted"}
+]}`, b.String())
+}
+
+func TestWriteSARIF(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	err := WriteSARIF(b, reportFindings, "1.2.3")
+
+	assert.Nil(t, err)
+	var log map[string]interface{}
+	err = json.Unmarshal(b.Bytes(), &log)
+	assert.Nil(t, err)
+	assert.Equal(t, "2.1.0", log["version"])
+	run := log["runs"].([]interface{})[0].(map[string]interface{})
+	driver := run["tool"].(map[string]interface{})["driver"].(map[string]interface{})
+	assert.Equal(t, "letsgopher", driver["name"])
+	assert.Equal(t, "1.2.3", driver["version"])
+	assert.Len(t, driver["rules"], len(Rules))
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"ruleId":    "template-syntax",
+			"ruleIndex": float64(1),
+			"level":     "error",
+			"message":   map[string]interface{}{"text": "template: main.go:3: unexpected EOF"},
+			"locations": []interface{}{map[string]interface{}{"physicalLocation": map[string]interface{}{
+				"artifactLocation": map[string]interface{}{"uri": "main.go"},
+				"region":           map[string]interface{}{"startLine": float64(3)},
+			}}},
+		},
+		map[string]interface{}{
+			"ruleId":    "binary-file",
+			"ruleIndex": float64(5),
+			"level":     "warning",
+			"message":   map[string]interface{}{"text": "binary file would be rendered as template and may be corrupted"},
+			"locations": []interface{}{map[string]interface{}{"physicalLocation": map[string]interface{}{
+				"artifactLocation": map[string]interface{}{"uri": "logo.png"},
+			}}},
+		},
+	}, run["results"])
+}
@@ -0,0 +1,81 @@
+package lint
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// File is a file or directory of a template. The name is the slash-separated path relative to the template root.
+type File struct {
+	Name    string
+	Dir     bool
+	Content []byte
+}
+
+// LoadDir loads the files of a template directory. Git metadata directories are ignored.
+func LoadDir(dir string) ([]*File, error) {
+	files := []*File{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			files = append(files, &File{Name: filepath.ToSlash(rel), Dir: true})
+			return nil
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files = append(files, &File{Name: filepath.ToSlash(rel), Content: b})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// LoadZIP loads the entries of a template archive.
+func LoadZIP(archiveFile string) ([]*File, error) {
+	r, err := zip.OpenReader(archiveFile)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := r.Close(); err != nil {
+			panic(err)
+		}
+	}()
+
+	files := []*File{}
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			files = append(files, &File{Name: f.Name, Dir: true})
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		b, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, &File{Name: f.Name, Content: b})
+	}
+	return files, nil
+}
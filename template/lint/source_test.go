@@ -0,0 +1,47 @@
+package lint
+
+import (
+	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/testhelper"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadDir(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	assert.Nil(t, os.MkdirAll(filepath.Join(tmpDir, "cmd"), 0755))
+	assert.Nil(t, os.MkdirAll(filepath.Join(tmpDir, ".git"), 0755))
+	testhelper.WriteFile(t, filepath.Join(tmpDir, "manifest.yaml"), "version: \"1.0.0\"", 0644)
+	testhelper.WriteFile(t, filepath.Join(tmpDir, "cmd", "main.go"), "package main", 0644)
+	testhelper.WriteFile(t, filepath.Join(tmpDir, ".git", "HEAD"), "ref: refs/heads/master", 0644)
+	files, err := LoadDir(tmpDir)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*File{
+		{Name: "cmd", Dir: true},
+		{Name: "cmd/main.go", Content: []byte("package main")},
+		{Name: "manifest.yaml", Content: []byte("version: \"1.0.0\"")},
+	}, files)
+}
+
+func TestLoadZIP(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	archiveZip := filepath.Join(tmpDir, "hello-world-1.0.0.zip")
+	testhelper.CreateZip(t, archiveZip, []testhelper.TestFile{
+		{Name: "manifest.yaml", Content: "version: \"1.0.0\""},
+		{Name: "../letsgopher-lint/main.go", Content: "package main"},
+	})
+	files, err := LoadZIP(archiveZip)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*File{
+		{Name: "manifest.yaml", Content: []byte("version: \"1.0.0\"")},
+		{Name: "../letsgopher-lint/main.go", Content: []byte("package main")},
+	}, files)
+}
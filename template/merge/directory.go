@@ -0,0 +1,166 @@
+package merge
+
+import (
+	"bytes"
+	"github.com/bmuschko/letsgopher/template/archive"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Action describes how a file has been changed by merging directories.
+type Action string
+
+const (
+	// Added represents a file introduced by theirs.
+	Added Action = "added"
+
+	// Updated represents a file combining the changes of ours and theirs.
+	Updated Action = "updated"
+
+	// Removed represents a file deleted by theirs and unchanged by ours.
+	Removed Action = "removed"
+
+	// Conflicted represents a file changed differently by ours and theirs.
+	// Text files contain conflict markers, binary files are left as is.
+	Conflicted Action = "conflict"
+
+	// Kept represents a file changed by theirs which has been deleted or changed by ours and therefore left as is.
+	Kept Action = "kept"
+)
+
+// Change describes the merge result of a single file. The path is relative to the merged directories.
+type Change struct {
+	Path   string
+	Action Action
+}
+
+// Directories merges the changes between the files of baseDir and theirsDir into oursDir.
+// Files only existing in oursDir are never touched. The returned changes are sorted by path.
+func Directories(baseDir string, theirsDir string, oursDir string, labels Labels) ([]*Change, error) {
+	basePaths, err := listFiles(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	theirsPaths, err := listFiles(theirsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := basePaths
+	for p := range theirsPaths {
+		paths[p] = true
+	}
+	sorted := []string{}
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	changes := []*Change{}
+	for _, p := range sorted {
+		action, err := mergeFile(p, baseDir, theirsDir, oursDir, labels)
+		if err != nil {
+			return nil, err
+		}
+		if action != "" {
+			changes = append(changes, &Change{Path: p, Action: action})
+		}
+	}
+	return changes, nil
+}
+
+func mergeFile(path string, baseDir string, theirsDir string, oursDir string, labels Labels) (Action, error) {
+	base, inBase, err := readFile(baseDir, path)
+	if err != nil {
+		return "", err
+	}
+	theirs, inTheirs, err := readFile(theirsDir, path)
+	if err != nil {
+		return "", err
+	}
+	ours, inOurs, err := readFile(oursDir, path)
+	if err != nil {
+		return "", err
+	}
+	target := filepath.Join(oursDir, path)
+
+	switch {
+	case !inTheirs:
+		if !inOurs {
+			return "", nil
+		}
+		if !bytes.Equal(ours, base) {
+			return Kept, nil
+		}
+		return Removed, os.Remove(target)
+	case !inOurs:
+		if inBase {
+			if bytes.Equal(base, theirs) {
+				return "", nil
+			}
+			return Kept, nil
+		}
+		return Added, writeFile(target, theirs, filepath.Join(theirsDir, path))
+	case bytes.Equal(ours, theirs), inBase && bytes.Equal(base, theirs):
+		return "", nil
+	case inBase && bytes.Equal(ours, base):
+		return Updated, writeFile(target, theirs, filepath.Join(theirsDir, path))
+	case archive.IsBinary(ours) || archive.IsBinary(theirs):
+		return Conflicted, nil
+	}
+
+	r := ThreeWay(base, ours, theirs, labels)
+	err = ioutil.WriteFile(target, r.Content, 0644)
+	if err != nil {
+		return "", err
+	}
+	if r.Conflicts > 0 {
+		return Conflicted, nil
+	}
+	return Updated, nil
+}
+
+func listFiles(dir string) (map[string]bool, error) {
+	paths := make(map[string]bool)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		paths[rel] = true
+		return nil
+	})
+	return paths, err
+}
+
+func readFile(dir string, path string) ([]byte, bool, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, path))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return b, true, nil
+}
+
+// writeFile writes content to a file using the permissions of the source file for newly created files.
+func writeFile(path string, content []byte, source string) error {
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(source); err == nil {
+		perm = info.Mode().Perm()
+	}
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, content, perm)
+}
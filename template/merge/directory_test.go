@@ -0,0 +1,64 @@
+package merge
+
+import (
+	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/testhelper"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirectories(t *testing.T) {
+	tmpDir := filet.TmpDir(t, "")
+	defer filet.CleanUp(t)
+
+	baseDir := filepath.Join(tmpDir, "base")
+	theirsDir := filepath.Join(tmpDir, "theirs")
+	oursDir := filepath.Join(tmpDir, "ours")
+	testhelper.WriteFiles(t, baseDir, map[string]string{
+		"unchanged.txt":        "a\n",
+		"template-changed.txt": "a\nb\n",
+		"both-changed.txt":     "a\nb\nc\n",
+		"conflict.txt":         "a\n",
+		"removed.txt":          "a\n",
+		"removed-modified.txt": "a\n",
+		"deleted-by-user.txt":  "a\n",
+	})
+	testhelper.WriteFiles(t, theirsDir, map[string]string{
+		"unchanged.txt":        "a\n",
+		"template-changed.txt": "a\nB\n",
+		"both-changed.txt":     "a\nb\nC\n",
+		"conflict.txt":         "theirs\n",
+		"deleted-by-user.txt":  "b\n",
+		"pkg/added.txt":        "new\n",
+	})
+	testhelper.WriteFiles(t, oursDir, map[string]string{
+		"unchanged.txt":        "a\n",
+		"template-changed.txt": "a\nb\n",
+		"both-changed.txt":     "A\nb\nc\n",
+		"conflict.txt":         "ours\n",
+		"removed.txt":          "a\n",
+		"removed-modified.txt": "modified\n",
+		"user.txt":             "mine\n",
+	})
+	changes, err := Directories(baseDir, theirsDir, oursDir, Labels{Ours: "current", Base: "1.0.0", Theirs: "2.0.0"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*Change{
+		{Path: "both-changed.txt", Action: Updated},
+		{Path: "conflict.txt", Action: Conflicted},
+		{Path: "deleted-by-user.txt", Action: Kept},
+		{Path: filepath.FromSlash("pkg/added.txt"), Action: Added},
+		{Path: "removed-modified.txt", Action: Kept},
+		{Path: "removed.txt", Action: Removed},
+		{Path: "template-changed.txt", Action: Updated},
+	}, changes)
+	assert.Equal(t, "A\nb\nC\n", testhelper.ReadFile(t, filepath.Join(oursDir, "both-changed.txt")))
+	assert.Equal(t, "<<<<<<< current\nours\n||||||| 1.0.0\na\n=======\ntheirs\n>>>>>>> 2.0.0\n", testhelper.ReadFile(t, filepath.Join(oursDir, "conflict.txt")))
+	assert.Equal(t, "new\n", testhelper.ReadFile(t, filepath.Join(oursDir, "pkg", "added.txt")))
+	assert.Equal(t, "modified\n", testhelper.ReadFile(t, filepath.Join(oursDir, "removed-modified.txt")))
+	testhelper.FileNotExists(t, filepath.Join(oursDir, "removed.txt"))
+	testhelper.FileNotExists(t, filepath.Join(oursDir, "deleted-by-user.txt"))
+	assert.Equal(t, "a\nB\n", testhelper.ReadFile(t, filepath.Join(oursDir, "template-changed.txt")))
+	assert.Equal(t, "mine\n", testhelper.ReadFile(t, filepath.Join(oursDir, "user.txt")))
+}
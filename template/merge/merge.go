@@ -0,0 +1,107 @@
+package merge
+
+import (
+	"bytes"
+	"github.com/bmuschko/letsgopher/template/diff"
+)
+
+const (
+	oursMarker   = "<<<<<<<"
+	baseMarker   = "|||||||"
+	splitMarker  = "======="
+	theirsMarker = ">>>>>>>"
+)
+
+// Labels names the versions of a file shown in conflict markers.
+type Labels struct {
+	Ours   string
+	Base   string
+	Theirs string
+}
+
+// Result is the outcome of a three-way merge.
+type Result struct {
+	Content   []byte
+	Conflicts int
+}
+
+// ThreeWay merges the changes between base and ours with the changes between base and theirs line by line.
+// Changes to different regions are combined, identical changes are applied once.
+// Regions changed differently on both sides are surrounded by conflict markers in the style of diff3.
+func ThreeWay(base []byte, ours []byte, theirs []byte, labels Labels) *Result {
+	b, o, t := diff.SplitLines(base), diff.SplitLines(ours), diff.SplitLines(theirs)
+	mo, mt := diff.Match(b, o), diff.Match(b, t)
+
+	out := bytes.NewBuffer(nil)
+	conflicts := 0
+	i, j, k := 0, 0, 0
+	for {
+		n := i
+		for n < len(b) && (mo[n] < 0 || mt[n] < 0) {
+			n++
+		}
+		oEnd, tEnd := len(o), len(t)
+		if n < len(b) {
+			oEnd, tEnd = mo[n], mt[n]
+		}
+		if resolve(out, b[i:n], o[j:oEnd], t[k:tEnd], labels) {
+			conflicts++
+		}
+		if n == len(b) {
+			break
+		}
+		out.WriteString(b[n])
+		i, j, k = n+1, oEnd+1, tEnd+1
+	}
+	return &Result{Content: out.Bytes(), Conflicts: conflicts}
+}
+
+// resolve writes the merged lines of a region not shared by all versions.
+// It returns true if both sides changed the region differently.
+func resolve(out *bytes.Buffer, base []string, ours []string, theirs []string, labels Labels) bool {
+	switch {
+	case equal(ours, theirs), equal(base, theirs):
+		writeLines(out, ours)
+	case equal(base, ours):
+		writeLines(out, theirs)
+	default:
+		writeMarker(out, oursMarker, labels.Ours)
+		writeLines(out, ours)
+		writeMarker(out, baseMarker, labels.Base)
+		writeLines(out, base)
+		writeMarker(out, splitMarker, "")
+		writeLines(out, theirs)
+		writeMarker(out, theirsMarker, labels.Theirs)
+		return true
+	}
+	return false
+}
+
+func writeLines(out *bytes.Buffer, lines []string) {
+	for _, l := range lines {
+		out.WriteString(l)
+	}
+}
+
+func writeMarker(out *bytes.Buffer, marker string, label string) {
+	if out.Len() > 0 && !bytes.HasSuffix(out.Bytes(), []byte("\n")) {
+		out.WriteString("\n")
+	}
+	out.WriteString(marker)
+	if label != "" {
+		out.WriteString(" " + label)
+	}
+	out.WriteString("\n")
+}
+
+func equal(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+package merge
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var labels = Labels{Ours: "current", Base: "1.0.0", Theirs: "2.0.0"}
+
+func TestThreeWayCombinesChangesToDifferentRegions(t *testing.T) {
+	base := "a\nb\nc\nd\ne\n"
+	ours := "a\nB\nc\nd\ne\n"
+	theirs := "a\nb\nc\nd\nE\nf\n"
+	r := ThreeWay([]byte(base), []byte(ours), []byte(theirs), labels)
+
+	assert.Equal(t, 0, r.Conflicts)
+	assert.Equal(t, "a\nB\nc\nd\nE\nf\n", string(r.Content))
+}
+
+func TestThreeWayAppliesIdenticalChangesOnce(t *testing.T) {
+	base := "a\nb\nc\n"
+	changed := "a\nx\nc\n"
+	r := ThreeWay([]byte(base), []byte(changed), []byte(changed), labels)
+
+	assert.Equal(t, 0, r.Conflicts)
+	assert.Equal(t, changed, string(r.Content))
+}
+
+func TestThreeWayKeepsDeletions(t *testing.T) {
+	base := "a\nb\nc\n"
+	ours := "a\nc\n"
+	theirs := "a\nb\nc\nd\n"
+	r := ThreeWay([]byte(base), []byte(ours), []byte(theirs), labels)
+
+	assert.Equal(t, 0, r.Conflicts)
+	assert.Equal(t, "a\nc\nd\n", string(r.Content))
+}
+
+func TestThreeWayMarksConflictingChanges(t *testing.T) {
+	base := "a\nb\nc\n"
+	ours := "a\nours\nc\n"
+	theirs := "a\ntheirs\nc\n"
+	r := ThreeWay([]byte(base), []byte(ours), []byte(theirs), labels)
+
+	assert.Equal(t, 1, r.Conflicts)
+	assert.Equal(t, `a
+<<<<<<< current
+ours
+||||||| 1.0.0
+b
+=======
+theirs
+>>>>>>> 2.0.0
+c
+`, string(r.Content))
+}
+
+func TestThreeWayWithoutBase(t *testing.T) {
+	r := ThreeWay(nil, []byte("same\n"), []byte("same\n"), labels)
+
+	assert.Equal(t, 0, r.Conflicts)
+	assert.Equal(t, "same\n", string(r.Content))
+
+	r = ThreeWay(nil, []byte("ours"), []byte("theirs"), labels)
+
+	assert.Equal(t, 1, r.Conflicts)
+	assert.Equal(t, "<<<<<<< current\nours\n||||||| 1.0.0\n=======\ntheirs\n>>>>>>> 2.0.0\n", string(r.Content))
+}
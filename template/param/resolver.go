@@ -0,0 +1,275 @@
+package param
+
+import (
+	"fmt"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/bmuschko/letsgopher/template/expression"
+	"github.com/bmuschko/letsgopher/template/prompt"
+	"strings"
+	"unicode"
+)
+
+const envVarPrefix = "LETSGOPHER_PARAM_"
+
+// Source describes where the value of a parameter has been resolved from.
+type Source string
+
+const (
+	// FlagSource represents a value provided with the command line option --param.
+	FlagSource Source = "--param"
+
+	// AnswersSource represents a value provided by an answers file.
+	AnswersSource Source = "answers file"
+
+	// EnvironmentSource represents a value provided by an environment variable.
+	EnvironmentSource Source = "environment"
+
+	// PromptSource represents a value requested from the user by a prompter.
+	PromptSource Source = "prompt"
+
+	// SkippedSource represents a parameter skipped because its condition is false.
+	// The value of the parameter is the declared fallback value, if any.
+	SkippedSource Source = "skipped"
+
+	// ComputedSource represents a value derived from other parameters by an expression.
+	ComputedSource Source = "computed"
+
+	// ImplicitSource represents a value provided by letsgopher itself, e.g. facts about an existing Go module.
+	ImplicitSource Source = "implicit"
+)
+
+// Precedence lists the sources in the order they are consulted for a parameter value.
+var Precedence = []Source{FlagSource, AnswersSource, EnvironmentSource, PromptSource}
+
+// Resolution describes how the value of a parameter has been resolved.
+type Resolution struct {
+	Parameter *config.Parameter
+	Source    Source
+	Key       string
+	Value     string
+}
+
+// Resolver determines parameter values from user-provided sources and falls back to prompting for missing values.
+// Params may hold multiple values for a parameter which are combined for list and map types.
+// Implicit values are available to all templates without declaring them and take precedence over any other source.
+type Resolver struct {
+	Params    map[string][]string
+	Answers   map[string]string
+	LookupEnv func(key string) (string, bool)
+	Prompter  prompt.Prompter
+	Implicit  map[string]interface{}
+}
+
+// EnvVarNames returns the names of the environment variables consulted for a parameter in order of precedence.
+func EnvVarNames(p *config.Parameter) []string {
+	names := []string{envVarPrefix + envVarSuffix(p.Name)}
+	if p.Env != "" {
+		names = append(names, p.Env)
+	}
+	return names
+}
+
+func envVarSuffix(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, name)
+}
+
+// Lookup determines the value of a parameter from the non-interactive sources.
+// The returned resolution uses PromptSource if none of the sources provide a value.
+func (r *Resolver) Lookup(p *config.Parameter) *Resolution {
+	if values, exist := r.Params[p.Name]; exist && len(values) > 0 {
+		return &Resolution{Parameter: p, Source: FlagSource, Value: combineValues(p, values)}
+	}
+	if value, exist := r.Answers[p.Name]; exist {
+		return &Resolution{Parameter: p, Source: AnswersSource, Value: value}
+	}
+	if r.LookupEnv != nil {
+		for _, key := range EnvVarNames(p) {
+			if value, exist := r.LookupEnv(key); exist {
+				return &Resolution{Parameter: p, Source: EnvironmentSource, Key: key, Value: value}
+			}
+		}
+	}
+	return &Resolution{Parameter: p, Source: PromptSource}
+}
+
+// Explain determines the resolution of all parameters without prompting the user.
+// Conditions are evaluated against the values known without prompting.
+func (r *Resolver) Explain(params []*config.Parameter) []*Resolution {
+	resolutions := []*Resolution{}
+	known := r.implicitValues()
+	for _, p := range params {
+		if value, exist := r.Implicit[p.Name]; exist {
+			resolutions = append(resolutions, &Resolution{Parameter: p, Source: ImplicitSource, Value: config.FormatValue(value)})
+			continue
+		}
+		skip, err := skipParameter(p, known)
+		if err == nil && skip {
+			resolutions = append(resolutions, &Resolution{Parameter: p, Source: SkippedSource, Value: p.Fallback})
+			if p.Fallback != "" {
+				known[p.Name], _ = p.ParseValue(p.Fallback)
+			}
+			continue
+		}
+		if p.Computed != "" {
+			value, err := computeValue(p, known)
+			res := &Resolution{Parameter: p, Source: ComputedSource}
+			if err == nil {
+				res.Value = config.FormatValue(value)
+				known[p.Name] = value
+			}
+			resolutions = append(resolutions, res)
+			continue
+		}
+		res := r.Lookup(p)
+		if res.Source != PromptSource {
+			if value, err := p.ParseValue(res.Value); err == nil {
+				known[p.Name] = value
+			}
+		}
+		resolutions = append(resolutions, res)
+	}
+	return resolutions
+}
+
+// Resolve determines the values of all parameters and returns them as replacements.
+// Computed parameters are evaluated against the values resolved so far and never requested from the user.
+// Parameters whose condition evaluates to false against the values resolved so far are skipped
+// and set to their fallback value, if declared.
+func (r *Resolver) Resolve(params []*config.Parameter) (map[string]interface{}, error) {
+	replacements := r.implicitValues()
+
+	for _, p := range params {
+		if _, exist := r.Implicit[p.Name]; exist {
+			continue
+		}
+		skip, err := skipParameter(p, replacements)
+		if err != nil {
+			return nil, err
+		}
+		if skip {
+			if p.Fallback != "" {
+				value, err := p.ParseValue(p.Fallback)
+				if err != nil {
+					return nil, err
+				}
+				replacements[p.Name] = value
+			}
+			continue
+		}
+
+		if p.Computed != "" {
+			value, err := computeValue(p, replacements)
+			if err != nil {
+				return nil, err
+			}
+			replacements[p.Name] = value
+			continue
+		}
+
+		res := r.Lookup(p)
+		if res.Source == PromptSource {
+			err := r.Prompter.Prompt(p, replacements)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		value, err := p.ParseValue(res.Value)
+		if err != nil {
+			return nil, fmt.Errorf("provided value for parameter %q is invalid: %s", p.Name, err)
+		}
+		err = checkEnum(p, value)
+		if err != nil {
+			return nil, err
+		}
+		err = p.Validate(value)
+		if err != nil {
+			return nil, fmt.Errorf("provided value for parameter %q is invalid: %s", p.Name, err)
+		}
+		replacements[p.Name] = value
+	}
+
+	return replacements, nil
+}
+
+// Describe renders the origin of a resolution in human-readable form.
+func (r *Resolution) Describe() string {
+	if r.Key != "" {
+		return fmt.Sprintf("%s (%s)", r.Source, r.Key)
+	}
+	return string(r.Source)
+}
+
+func (r *Resolver) implicitValues() map[string]interface{} {
+	values := make(map[string]interface{})
+	for k, v := range r.Implicit {
+		values[k] = v
+	}
+	return values
+}
+
+func skipParameter(p *config.Parameter, replacements map[string]interface{}) (bool, error) {
+	if p.When == "" {
+		return false, nil
+	}
+	ok, err := expression.EvaluateCondition(p.When, replacements)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate condition of parameter %q: %s", p.Name, err)
+	}
+	return !ok, nil
+}
+
+func computeValue(p *config.Parameter, replacements map[string]interface{}) (interface{}, error) {
+	s, err := expression.Evaluate(p.Computed, replacements)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute value of parameter %q: %s", p.Name, err)
+	}
+	value, err := p.ParseValue(s)
+	if err != nil {
+		return nil, fmt.Errorf("computed value for parameter %q is invalid: %s", p.Name, err)
+	}
+	err = p.Validate(value)
+	if err != nil {
+		return nil, fmt.Errorf("computed value for parameter %q is invalid: %s", p.Name, err)
+	}
+	return value, nil
+}
+
+func combineValues(p *config.Parameter, values []string) string {
+	if p.Type == config.ListType || p.Type == config.MapType {
+		return strings.Join(values, ",")
+	}
+	return values[len(values)-1]
+}
+
+func checkEnum(p *config.Parameter, value interface{}) error {
+	if p.Enum == nil {
+		return nil
+	}
+	values := []string{config.FormatValue(value)}
+	if items, ok := value.([]string); ok {
+		values = items
+	}
+	for _, v := range values {
+		if !contains(p.Enum, v) {
+			return fmt.Errorf("provided value '%s' is not defined in enum [%s]",
+				v, strings.Join(p.Enum, ", "))
+		}
+	}
+	return nil
+}
+
+func contains(s []string, e string) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,289 @@
+package param
+
+import (
+	"errors"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestEnvVarNamesWithoutDeclaredEnv(t *testing.T) {
+	p := &config.Parameter{Name: "appName"}
+
+	assert.Equal(t, []string{"LETSGOPHER_PARAM_APPNAME"}, EnvVarNames(p))
+}
+
+func TestEnvVarNamesWithDeclaredEnv(t *testing.T) {
+	p := &config.Parameter{Name: "go-version", Env: "GO_VERSION"}
+
+	assert.Equal(t, []string{"LETSGOPHER_PARAM_GO_VERSION", "GO_VERSION"}, EnvVarNames(p))
+}
+
+func TestLookupPrecedence(t *testing.T) {
+	p := &config.Parameter{Name: "module", Env: "MODULE"}
+	env := map[string]string{"LETSGOPHER_PARAM_MODULE": "env", "MODULE": "declared"}
+	lookups := []struct {
+		name     string
+		resolver *Resolver
+		source   Source
+		key      string
+		value    string
+	}{
+		{"flag", &Resolver{Params: map[string][]string{"module": {"flag"}}, Answers: map[string]string{"module": "answers"}, LookupEnv: lookup(env)}, FlagSource, "", "flag"},
+		{"answers", &Resolver{Answers: map[string]string{"module": "answers"}, LookupEnv: lookup(env)}, AnswersSource, "", "answers"},
+		{"env", &Resolver{LookupEnv: lookup(env)}, EnvironmentSource, "LETSGOPHER_PARAM_MODULE", "env"},
+		{"declared env", &Resolver{LookupEnv: lookup(map[string]string{"MODULE": "declared"})}, EnvironmentSource, "MODULE", "declared"},
+		{"prompt", &Resolver{}, PromptSource, "", ""},
+	}
+	for _, l := range lookups {
+		t.Run(l.name, func(t *testing.T) {
+			res := l.resolver.Lookup(p)
+
+			assert.Equal(t, l.source, res.Source)
+			assert.Equal(t, l.key, res.Key)
+			assert.Equal(t, l.value, res.Value)
+		})
+	}
+}
+
+func TestResolveCombinesSourcesAndPrompter(t *testing.T) {
+	params := []*config.Parameter{
+		{Name: "a", Type: config.StringType},
+		{Name: "b", Type: config.StringType},
+		{Name: "c", Type: config.StringType},
+	}
+	pM := new(PrompterMock)
+	resolver := &Resolver{
+		Params:    map[string][]string{"a": {"flag"}},
+		LookupEnv: lookup(map[string]string{"LETSGOPHER_PARAM_B": "env"}),
+		Prompter:  pM,
+	}
+	pM.On("Prompt", params[2], mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(map[string]interface{})["c"] = "prompted"
+	})
+	replacements, err := resolver.Resolve(params)
+
+	pM.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"a": "flag", "b": "env", "c": "prompted"}, replacements)
+}
+
+func TestResolveRejectsValueNotInEnum(t *testing.T) {
+	params := []*config.Parameter{
+		{Name: "a", Type: config.StringType, Enum: []string{"x", "y"}},
+	}
+	resolver := &Resolver{LookupEnv: lookup(map[string]string{"LETSGOPHER_PARAM_A": "z"})}
+	replacements, err := resolver.Resolve(params)
+
+	assert.Nil(t, replacements)
+	assert.NotNil(t, err)
+	assert.Equal(t, "provided value 'z' is not defined in enum [x, y]", err.Error())
+}
+
+func TestResolvePropagatesPromptError(t *testing.T) {
+	params := []*config.Parameter{
+		{Name: "a", Type: config.StringType},
+	}
+	pM := new(PrompterMock)
+	resolver := &Resolver{Prompter: pM}
+	pM.On("Prompt", params[0], mock.Anything).Return(errors.New("interrupted"))
+	replacements, err := resolver.Resolve(params)
+
+	pM.AssertExpectations(t)
+	assert.Nil(t, replacements)
+	assert.NotNil(t, err)
+	assert.Equal(t, "interrupted", err.Error())
+}
+
+func lookup(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, exist := env[key]
+		return value, exist
+	}
+}
+
+type PrompterMock struct {
+	mock.Mock
+}
+
+func (p *PrompterMock) Prompt(param *config.Parameter, replacements map[string]interface{}) error {
+	args := p.Called(param, replacements)
+	return args.Error(0)
+}
+
+func TestResolveConvertsValuesToParameterType(t *testing.T) {
+	params := []*config.Parameter{
+		{Name: "port", Type: config.IntegerType},
+		{Name: "services", Type: config.ListType, Enum: []string{"api", "worker", "cron"}},
+		{Name: "labels", Type: config.MapType},
+	}
+	resolver := &Resolver{
+		Params:  map[string][]string{"services": {"api", "worker"}, "labels": {"team=core", "tier=backend"}},
+		Answers: map[string]string{"port": "8080"},
+	}
+	replacements, err := resolver.Resolve(params)
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"port":     8080,
+		"services": []string{"api", "worker"},
+		"labels":   map[string]string{"team": "core", "tier": "backend"},
+	}, replacements)
+}
+
+func TestResolveRejectsListItemNotInEnum(t *testing.T) {
+	params := []*config.Parameter{
+		{Name: "services", Type: config.ListType, Enum: []string{"api", "worker"}},
+	}
+	resolver := &Resolver{Params: map[string][]string{"services": {"api", "cron"}}}
+	replacements, err := resolver.Resolve(params)
+
+	assert.Nil(t, replacements)
+	assert.NotNil(t, err)
+	assert.Equal(t, "provided value 'cron' is not defined in enum [api, worker]", err.Error())
+}
+
+func TestResolveRejectsValueOfWrongType(t *testing.T) {
+	params := []*config.Parameter{
+		{Name: "contact", Type: config.EmailType},
+	}
+	resolver := &Resolver{Params: map[string][]string{"contact": {"nobody"}}}
+	replacements, err := resolver.Resolve(params)
+
+	assert.Nil(t, replacements)
+	assert.NotNil(t, err)
+	assert.Equal(t, "provided value for parameter \"contact\" is invalid: invalid email address \"nobody\": mail: missing '@' or angle-addr", err.Error())
+}
+
+func TestResolveRejectsValueViolatingValidationRules(t *testing.T) {
+	params := []*config.Parameter{
+		{Name: "name", Type: config.StringType, Pattern: "^[a-z-]+$", ValidationMessage: "use kebab-case"},
+	}
+	resolver := &Resolver{Params: map[string][]string{"name": {"HelloWorld"}}}
+	replacements, err := resolver.Resolve(params)
+
+	assert.Nil(t, replacements)
+	assert.NotNil(t, err)
+	assert.Equal(t, "provided value for parameter \"name\" is invalid: use kebab-case", err.Error())
+}
+
+func TestResolveSkipsParametersWithFalseCondition(t *testing.T) {
+	params := []*config.Parameter{
+		{Name: "useDatabase", Type: config.BooleanType},
+		{Name: "database", Type: config.StringType, When: ".useDatabase"},
+		{Name: "port", Type: config.IntegerType, When: ".useDatabase", Fallback: "0"},
+	}
+	pM := new(PrompterMock)
+	resolver := &Resolver{Params: map[string][]string{"useDatabase": {"false"}}, Prompter: pM}
+	replacements, err := resolver.Resolve(params)
+
+	pM.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"useDatabase": false, "port": 0}, replacements)
+}
+
+func TestResolveRequestsParametersWithTrueCondition(t *testing.T) {
+	params := []*config.Parameter{
+		{Name: "useDatabase", Type: config.BooleanType},
+		{Name: "database", Type: config.StringType, When: ".useDatabase"},
+	}
+	pM := new(PrompterMock)
+	resolver := &Resolver{Params: map[string][]string{"useDatabase": {"true"}}, Prompter: pM}
+	pM.On("Prompt", params[1], mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(map[string]interface{})["database"] = "postgres"
+	})
+	replacements, err := resolver.Resolve(params)
+
+	pM.AssertExpectations(t)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"useDatabase": true, "database": "postgres"}, replacements)
+}
+
+func TestExplainMarksSkippedParameters(t *testing.T) {
+	params := []*config.Parameter{
+		{Name: "useDatabase", Type: config.BooleanType},
+		{Name: "database", Type: config.StringType, When: ".useDatabase", Fallback: "none"},
+	}
+	resolver := &Resolver{Params: map[string][]string{"useDatabase": {"false"}, "database": {"postgres"}}}
+	resolutions := resolver.Explain(params)
+
+	assert.Equal(t, 2, len(resolutions))
+	assert.Equal(t, FlagSource, resolutions[0].Source)
+	assert.Equal(t, SkippedSource, resolutions[1].Source)
+	assert.Equal(t, "none", resolutions[1].Value)
+}
+
+func TestResolveComputesDerivedParameters(t *testing.T) {
+	params := []*config.Parameter{
+		{Name: "org", Type: config.StringType},
+		{Name: "name", Type: config.StringType},
+		{Name: "module", Type: config.ModulePathType, Computed: "github.com/{{ .org }}/{{ .name }}"},
+		{Name: "package", Type: config.StringType, Computed: "packageName .name"},
+	}
+	resolver := &Resolver{Params: map[string][]string{"org": {"acme"}, "name": {"order-service"}, "package": {"ignored"}}}
+	replacements, err := resolver.Resolve(params)
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"org":     "acme",
+		"name":    "order-service",
+		"module":  "github.com/acme/order-service",
+		"package": "orderservice",
+	}, replacements)
+}
+
+func TestResolveRejectsInvalidComputedValue(t *testing.T) {
+	params := []*config.Parameter{
+		{Name: "name", Type: config.StringType},
+		{Name: "module", Type: config.ModulePathType, Computed: "github.com/{{ .name }}"},
+	}
+	resolver := &Resolver{Params: map[string][]string{"name": {"my service"}}}
+	replacements, err := resolver.Resolve(params)
+
+	assert.Nil(t, replacements)
+	assert.NotNil(t, err)
+	assert.Equal(t, "computed value for parameter \"module\" is invalid: malformed module path \"github.com/my service\": invalid char ' '", err.Error())
+}
+
+func TestExplainShowsComputedParameters(t *testing.T) {
+	params := []*config.Parameter{
+		{Name: "name", Type: config.StringType},
+		{Name: "package", Type: config.StringType, Computed: "packageName .name"},
+	}
+	resolver := &Resolver{Params: map[string][]string{"name": {"order-service"}}}
+	resolutions := resolver.Explain(params)
+
+	assert.Equal(t, ComputedSource, resolutions[1].Source)
+	assert.Equal(t, "orderservice", resolutions[1].Value)
+}
+
+func TestResolveProvidesImplicitValues(t *testing.T) {
+	params := []*config.Parameter{
+		{Name: "modulePath", Type: config.ModulePathType},
+		{Name: "importPath", Type: config.StringType, Computed: "{{ .modulePath }}/handler"},
+	}
+	resolver := &Resolver{
+		Params:   map[string][]string{"modulePath": {"ignored"}},
+		Implicit: map[string]interface{}{"modulePath": "github.com/acme/hello", "moduleGoVersion": "1.13"},
+	}
+	replacements, err := resolver.Resolve(params)
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"modulePath":      "github.com/acme/hello",
+		"moduleGoVersion": "1.13",
+		"importPath":      "github.com/acme/hello/handler",
+	}, replacements)
+}
+
+func TestExplainShowsImplicitParameters(t *testing.T) {
+	params := []*config.Parameter{
+		{Name: "modulePath", Type: config.ModulePathType},
+	}
+	resolver := &Resolver{Implicit: map[string]interface{}{"modulePath": "github.com/acme/hello"}}
+	resolutions := resolver.Explain(params)
+
+	assert.Equal(t, ImplicitSource, resolutions[0].Source)
+	assert.Equal(t, "github.com/acme/hello", resolutions[0].Value)
+}
@@ -0,0 +1,6 @@
+package prompt
+
+// Confirmer asks the user for the confirmation of an action.
+type Confirmer interface {
+	Confirm(message string) (bool, error)
+}
@@ -0,0 +1,32 @@
+package prompt
+
+import (
+	"fmt"
+	"github.com/bmuschko/letsgopher/template/config"
+)
+
+// DefaultPrompter answers prompts without user interaction by using the default value of the parameter.
+// It fails for parameters which don't declare a default value.
+type DefaultPrompter struct {
+}
+
+// Prompt sets the parameter to its default value rendered against the values resolved so far.
+func (dp *DefaultPrompter) Prompt(p *config.Parameter, replacements map[string]interface{}) error {
+	defaultValue, err := p.RenderDefaultValue(replacements)
+	if err != nil {
+		return err
+	}
+	if defaultValue == "" {
+		return fmt.Errorf("parameter %q needs to be provided as it doesn't declare a default value", p.Name)
+	}
+	value, err := p.ParseValue(defaultValue)
+	if err != nil {
+		return fmt.Errorf("default value of parameter %q is invalid: %s", p.Name, err)
+	}
+	err = p.Validate(value)
+	if err != nil {
+		return fmt.Errorf("default value of parameter %q is invalid: %s", p.Name, err)
+	}
+	replacements[p.Name] = value
+	return nil
+}
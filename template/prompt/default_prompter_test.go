@@ -0,0 +1,35 @@
+package prompt
+
+import (
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDefaultPrompterUsesRenderedDefaultValue(t *testing.T) {
+	replacements := map[string]interface{}{"name": "Hello World"}
+	p := &config.Parameter{Name: "binary", Type: config.StringType, DefaultValue: "{{ kebabCase .name }}"}
+	err := (&DefaultPrompter{}).Prompt(p, replacements)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "hello-world", replacements["binary"])
+}
+
+func TestDefaultPrompterParsesDefaultValue(t *testing.T) {
+	replacements := make(map[string]interface{})
+	p := &config.Parameter{Name: "replicas", Type: config.IntegerType, DefaultValue: "3"}
+	err := (&DefaultPrompter{}).Prompt(p, replacements)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, replacements["replicas"])
+}
+
+func TestDefaultPrompterFailsWithoutDefaultValue(t *testing.T) {
+	replacements := make(map[string]interface{})
+	p := &config.Parameter{Name: "module", Type: config.ModulePathType}
+	err := (&DefaultPrompter{}).Prompt(p, replacements)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "parameter \"module\" needs to be provided as it doesn't declare a default value", err.Error())
+	assert.Empty(t, replacements)
+}
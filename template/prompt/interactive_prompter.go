@@ -6,6 +6,7 @@ import (
 	"gopkg.in/AlecAivazis/survey.v1"
 	"gopkg.in/AlecAivazis/survey.v1/core"
 	"strconv"
+	"strings"
 )
 
 // InteractivePrompter ask for user input interactively on the console.
@@ -17,7 +18,16 @@ func init() {
 }
 
 // Prompt requests user input from the console.
+// The default value of the parameter is rendered against the values resolved so far.
 func (ip *InteractivePrompter) Prompt(p *config.Parameter, replacements map[string]interface{}) error {
+	defaultValue, err := p.RenderDefaultValue(replacements)
+	if err != nil {
+		return err
+	}
+	rendered := *p
+	rendered.DefaultValue = defaultValue
+	p = &rendered
+
 	if p.Type == config.StringType {
 		value, err := promptString(p)
 		if err != nil {
@@ -36,6 +46,24 @@ func (ip *InteractivePrompter) Prompt(p *config.Parameter, replacements map[stri
 			return err
 		}
 		replacements[p.Name] = value
+	} else if p.Type == config.ListType {
+		value, err := promptList(p)
+		if err != nil {
+			return err
+		}
+		replacements[p.Name] = value
+	} else if p.Type == config.MapType {
+		value, err := promptMap(p)
+		if err != nil {
+			return err
+		}
+		replacements[p.Name] = value
+	} else if isValidatedStringType(p.Type) {
+		value, err := promptValidatedString(p)
+		if err != nil {
+			return err
+		}
+		replacements[p.Name] = value
 	} else {
 		return fmt.Errorf("unknown parameter type %s", p.Type)
 	}
@@ -58,7 +86,15 @@ func promptString(p *config.Parameter) (string, error) {
 		if p.DefaultValue != "" {
 			prompt.Default = p.DefaultValue
 		}
-		err = survey.AskOne(prompt, &value, survey.Required)
+		err = survey.AskOne(prompt, &value, survey.ComposeValidators(survey.Required, newValidator(p)))
+	} else if p.Secret {
+		prompt := &survey.Password{
+			Message: p.Prompt,
+		}
+		if p.Description != "" {
+			prompt.Help = p.Description
+		}
+		err = survey.AskOne(prompt, &value, survey.ComposeValidators(survey.Required, newValidator(p)))
 	} else {
 		prompt := &survey.Input{
 			Message: p.Prompt,
@@ -69,7 +105,7 @@ func promptString(p *config.Parameter) (string, error) {
 		if p.DefaultValue != "" {
 			prompt.Default = p.DefaultValue
 		}
-		err = survey.AskOne(prompt, &value, survey.Required)
+		err = survey.AskOne(prompt, &value, survey.ComposeValidators(survey.Required, newValidator(p)))
 	}
 	if err != nil {
 		return "", err
@@ -92,7 +128,7 @@ func promptInteger(p *config.Parameter) (int, error) {
 		if p.DefaultValue != "" {
 			prompt.Default = p.DefaultValue
 		}
-		err = survey.AskOne(prompt, &value, survey.Required)
+		err = survey.AskOne(prompt, &value, survey.ComposeValidators(survey.Required, newValidator(p)))
 	} else {
 		prompt := &survey.Input{
 			Message: p.Prompt,
@@ -103,7 +139,7 @@ func promptInteger(p *config.Parameter) (int, error) {
 		if p.DefaultValue != "" {
 			prompt.Default = p.DefaultValue
 		}
-		err = survey.AskOne(prompt, &value, survey.Required)
+		err = survey.AskOne(prompt, &value, survey.ComposeValidators(survey.Required, newValidator(p)))
 	}
 	if err != nil {
 		return 0, err
@@ -119,16 +155,174 @@ func promptBoolean(p *config.Parameter) (bool, error) {
 	if p.Description != "" {
 		prompt.Help = p.Description
 	}
+	if p.DefaultValue != "" {
+		b, err := strconv.ParseBool(p.DefaultValue)
+		if err != nil {
+			return false, err
+		}
+		prompt.Default = b
+	}
 	err := survey.AskOne(prompt, &value, survey.Required)
 	if err != nil {
 		return false, err
 	}
+	return value, nil
+}
+
+func promptList(p *config.Parameter) ([]string, error) {
+	value := []string{}
+	var err error
+
+	if p.Enum != nil {
+		prompt := &survey.MultiSelect{
+			Message: p.Prompt,
+			Options: p.Enum,
+		}
+		if p.Description != "" {
+			prompt.Help = p.Description
+		}
+		if p.DefaultValue != "" {
+			prompt.Default, err = listDefault(p)
+			if err != nil {
+				return nil, err
+			}
+		}
+		err = survey.AskOne(prompt, &value, newValidator(p))
+	} else {
+		input := ""
+		prompt := &survey.Input{
+			Message: p.Prompt + " (comma-separated)",
+		}
+		if p.Description != "" {
+			prompt.Help = p.Description
+		}
+		if p.DefaultValue != "" {
+			prompt.Default = p.DefaultValue
+		}
+		err = survey.AskOne(prompt, &input, newValidator(p))
+		if err == nil {
+			var parsed interface{}
+			parsed, err = p.ParseValue(input)
+			if err == nil {
+				value = parsed.([]string)
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// listDefault parses the default value of a list parameter the same way as values entered by the user.
+func listDefault(p *config.Parameter) ([]string, error) {
+	parsed, err := p.ParseValue(p.DefaultValue)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.([]string), nil
+}
+
+func promptMap(p *config.Parameter) (map[string]string, error) {
+	value := make(map[string]string)
 	if p.DefaultValue != "" {
-		b, err := strconv.ParseBool(p.DefaultValue)
+		defaults, err := p.ParseValue(p.DefaultValue)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
-		prompt.Default = b
+		value = defaults.(map[string]string)
+	}
+
+	for {
+		entry := ""
+		prompt := &survey.Input{
+			Message: fmt.Sprintf("%s (key=value, leave empty to finish)", p.Prompt),
+		}
+		if p.Description != "" {
+			prompt.Help = p.Description
+		}
+		err := survey.AskOne(prompt, &entry, validateMapEntry)
+		if err != nil {
+			return nil, err
+		}
+		if entry == "" {
+			return value, nil
+		}
+		kv := strings.SplitN(entry, "=", 2)
+		value[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+}
+
+func validateMapEntry(ans interface{}) error {
+	entry, _ := ans.(string)
+	if entry == "" {
+		return nil
+	}
+	kv := strings.SplitN(entry, "=", 2)
+	if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+		return fmt.Errorf("entry %q does not separate key and value by = character", entry)
+	}
+	return nil
+}
+
+func isValidatedStringType(t string) bool {
+	switch t {
+	case config.PathType, config.URLType, config.EmailType, config.SemVerType, config.ModulePathType:
+		return true
+	}
+	return false
+}
+
+func promptValidatedString(p *config.Parameter) (string, error) {
+	value := ""
+	prompt := &survey.Input{
+		Message: p.Prompt,
+	}
+	if p.Description != "" {
+		prompt.Help = p.Description
+	}
+	if p.DefaultValue != "" {
+		prompt.Default = p.DefaultValue
+	}
+	err := survey.AskOne(prompt, &value, survey.ComposeValidators(survey.Required, newValidator(p)))
+	if err != nil {
+		return "", err
+	}
+	parsed, err := p.ParseValue(value)
+	if err != nil {
+		return "", err
+	}
+	return parsed.(string), nil
+}
+
+func newValidator(p *config.Parameter) survey.Validator {
+	return func(ans interface{}) error {
+		var value interface{}
+		switch a := ans.(type) {
+		case string:
+			v, err := p.ParseValue(a)
+			if err != nil {
+				return err
+			}
+			value = v
+		case []string:
+			value = a
+		default:
+			return nil
+		}
+		return p.Validate(value)
+	}
+}
+
+// Confirm requests a yes or no answer from the console. The answer defaults to no.
+func (ip *InteractivePrompter) Confirm(message string) (bool, error) {
+	value := false
+	prompt := &survey.Confirm{
+		Message: message,
+	}
+	err := survey.AskOne(prompt, &value, nil)
+	if err != nil {
+		return false, err
 	}
 	return value, nil
 }
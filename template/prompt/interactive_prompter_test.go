@@ -0,0 +1,15 @@
+package prompt
+
+import (
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestListDefaultTrimsValues(t *testing.T) {
+	p := &config.Parameter{Name: "components", Type: config.ListType, Enum: []string{"api", "worker"}, DefaultValue: "api, worker"}
+	values, err := listDefault(p)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"api", "worker"}, values)
+}
@@ -0,0 +1,359 @@
+package templatetest
+
+import (
+	"errors"
+	"fmt"
+	"github.com/bmuschko/letsgopher/template/archive"
+	"github.com/bmuschko/letsgopher/template/config"
+	"github.com/bmuschko/letsgopher/template/diff"
+	"github.com/bmuschko/letsgopher/template/generate"
+	"github.com/bmuschko/letsgopher/template/param"
+	"github.com/bmuschko/letsgopher/template/prompt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	// TestsDir is the directory of a template containing its test cases, one subdirectory per test case.
+	TestsDir = "tests"
+
+	// AnswersFileName is the name of the file of a test case providing the parameter values.
+	AnswersFileName = "answers.yaml"
+
+	// ExpectedDirName is the name of the directory of a test case containing the expected project files.
+	ExpectedDirName = "expected"
+)
+
+// Case is a test case of a template rendering the template with the values of an answers file.
+type Case struct {
+	Name string
+	Dir  string
+}
+
+// AnswersFile returns the path of the answers file of the test case.
+func (c *Case) AnswersFile() string {
+	return filepath.Join(c.Dir, AnswersFileName)
+}
+
+// ExpectedDir returns the path of the directory containing the expected project files of the test case.
+func (c *Case) ExpectedDir() string {
+	return filepath.Join(c.Dir, ExpectedDirName)
+}
+
+// MismatchKind describes how a generated file deviates from the expected files.
+type MismatchKind string
+
+const (
+	// ContentMismatch is a generated file whose content differs from the expected file.
+	ContentMismatch MismatchKind = "differs"
+
+	// MissingFile is an expected file which hasn't been generated.
+	MissingFile MismatchKind = "is missing"
+
+	// UnexpectedFile is a generated file which isn't expected.
+	UnexpectedFile MismatchKind = "is unexpected"
+)
+
+// Mismatch is a file of a test case deviating from the expected files.
+// The diff renders the changes from the expected to the generated content in the unified diff format.
+type Mismatch struct {
+	File string
+	Kind MismatchKind
+	Diff string
+}
+
+// Result is the outcome of running a test case.
+type Result struct {
+	Case       *Case
+	Mismatches []*Mismatch
+	// Updated indicates that the expected files have been rewritten with the generated files.
+	Updated bool
+	// Err is the reason the test case couldn't be rendered.
+	Err error
+}
+
+// Passed determines whether the generated files of the test case match the expected files.
+func (r *Result) Passed() bool {
+	return r.Err == nil && (len(r.Mismatches) == 0 || r.Updated)
+}
+
+// Harness renders the test cases of a template and compares the generated with the expected files.
+// Parameters without a value in the answers file are set to their default value instead of prompting.
+// Hooks and dependencies of the template are not run.
+type Harness struct {
+	// Implicit provides the values of parameters available to the template without declaring them.
+	Implicit map[string]interface{}
+
+	// Update rewrites the expected files of a test case with the generated files instead of reporting mismatches.
+	Update bool
+}
+
+// LoadCases determines the test cases of a template directory ordered by name.
+func LoadCases(templateDir string) ([]*Case, error) {
+	testsDir := filepath.Join(templateDir, TestsDir)
+	infos, err := ioutil.ReadDir(testsDir)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("template %q needs to contain a %s directory with test cases", templateDir, TestsDir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	cases := []*Case{}
+	for _, info := range infos {
+		if info.IsDir() {
+			cases = append(cases, &Case{Name: info.Name(), Dir: filepath.Join(testsDir, info.Name())})
+		}
+	}
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("template %q does not define any test cases in its %s directory", templateDir, TestsDir)
+	}
+	return cases, nil
+}
+
+// Run renders all test cases of a template directory.
+// An error is returned if the test cases or the manifest of the template can't be loaded.
+// Failures of individual test cases are reported by their results.
+func (h *Harness) Run(templateDir string) ([]*Result, error) {
+	cases, err := LoadCases(templateDir)
+	if err != nil {
+		return nil, err
+	}
+	m, archiveFile, cleanUp, err := prepare(templateDir)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanUp()
+
+	results := []*Result{}
+	for _, c := range cases {
+		results = append(results, h.runCase(archiveFile, m, c))
+	}
+	return results, nil
+}
+
+// RunCase renders a single test case of a template directory.
+func (h *Harness) RunCase(templateDir string, c *Case) (*Result, error) {
+	m, archiveFile, cleanUp, err := prepare(templateDir)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanUp()
+	return h.runCase(archiveFile, m, c), nil
+}
+
+func (h *Harness) runCase(archiveFile string, m *config.ManifestFile, c *Case) *Result {
+	result := &Result{Case: c, Mismatches: []*Mismatch{}}
+	projectDir, err := ioutil.TempDir("", "letsgopher-test-")
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer os.RemoveAll(projectDir)
+
+	err = h.render(archiveFile, m, c, projectDir)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Mismatches, err = compare(c.ExpectedDir(), projectDir)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	if h.Update && len(result.Mismatches) > 0 {
+		err = replaceDir(projectDir, c.ExpectedDir())
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		result.Updated = true
+	}
+	return result
+}
+
+func (h *Harness) render(archiveFile string, m *config.ManifestFile, c *Case, projectDir string) error {
+	answers := make(map[string]string)
+	if _, err := os.Stat(c.AnswersFile()); err == nil {
+		answers, err = config.LoadAnswersFile(c.AnswersFile())
+		if err != nil {
+			return fmt.Errorf("failed to load answers file %q: %s", c.AnswersFile(), err)
+		}
+	}
+	resolver := &param.Resolver{Answers: answers, Prompter: &prompt.DefaultPrompter{}, Implicit: h.implicit(m, answers)}
+	replacements, err := resolver.Resolve(m.Parameters)
+	if err != nil {
+		return err
+	}
+
+	archiver := &archive.ZIPArchiver{Processor: &archive.TemplateProcessor{}}
+	archives := []string{archiveFile}
+	postProcessors, err := generate.PostProcessors(archiver, archives, m, replacements, false)
+	if err != nil {
+		return err
+	}
+	return archiver.ExtractLayers(archives, projectDir, replacements, postProcessors...)
+}
+
+// implicit determines the values of the implicit parameters of a test case.
+// The answers file may provide the parameters describing the Go module a component is added to, e.g. modulePath,
+// unless the template declares a parameter of the same name. Implicit values of the harness take precedence.
+func (h *Harness) implicit(m *config.ManifestFile, answers map[string]string) map[string]interface{} {
+	implicit := make(map[string]interface{})
+	for _, name := range generate.ModuleParams {
+		if v, ok := answers[name]; ok && !declares(m, name) {
+			implicit[name] = v
+		}
+	}
+	for k, v := range h.Implicit {
+		implicit[k] = v
+	}
+	return implicit
+}
+
+func declares(m *config.ManifestFile, name string) bool {
+	for _, p := range m.Parameters {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// prepare loads the manifest of a template directory and packages the template to render its test cases.
+// The returned function removes the archive.
+func prepare(templateDir string) (*config.ManifestFile, string, func(), error) {
+	m, err := loadManifest(templateDir)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	archiveFile, cleanUp, err := packageTemplate(templateDir)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return m, archiveFile, cleanUp, nil
+}
+
+func loadManifest(templateDir string) (*config.ManifestFile, error) {
+	path := filepath.Join(templateDir, config.ManifestFileName)
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m, err := config.LoadManifestData(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", path, err)
+	}
+	err = config.ValidateManifest(m)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", path, err)
+	}
+	if m.Extends != nil {
+		return nil, errors.New("test cases of templates extending another template are not supported")
+	}
+	return m, nil
+}
+
+// packageTemplate bundles the template directory into a temporary archive excluding its test cases.
+func packageTemplate(templateDir string) (string, func(), error) {
+	dir, err := ioutil.TempDir("", "letsgopher-template-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanUp := func() { os.RemoveAll(dir) }
+	archiveFile := filepath.Join(dir, "template.zip")
+	err = archive.PackageExcluding(templateDir, archiveFile, archive.ZIPFormat, "/"+TestsDir+"/")
+	if err != nil {
+		cleanUp()
+		return "", nil, err
+	}
+	return archiveFile, cleanUp, nil
+}
+
+// compare determines the files of the generated project deviating from the expected files ordered by path.
+func compare(expectedDir string, projectDir string) ([]*Mismatch, error) {
+	expected, err := listFiles(expectedDir)
+	if err != nil {
+		return nil, err
+	}
+	generated, err := listFiles(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for name := range expected {
+		names = append(names, name)
+	}
+	for name := range generated {
+		if _, exists := expected[name]; !exists {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	mismatches := []*Mismatch{}
+	for _, name := range names {
+		e, isExpected := expected[name]
+		g, isGenerated := generated[name]
+		switch {
+		case !isGenerated:
+			mismatches = append(mismatches, &Mismatch{File: name, Kind: MissingFile, Diff: diff.Unified("expected/"+name, "/dev/null", e, nil)})
+		case !isExpected:
+			mismatches = append(mismatches, &Mismatch{File: name, Kind: UnexpectedFile, Diff: diff.Unified("/dev/null", "generated/"+name, nil, g)})
+		case string(e) != string(g):
+			mismatches = append(mismatches, &Mismatch{File: name, Kind: ContentMismatch, Diff: diff.Unified("expected/"+name, "generated/"+name, e, g)})
+		}
+	}
+	return mismatches, nil
+}
+
+// listFiles reads the content of all files of a directory by their slash-separated path.
+// A directory which doesn't exist contains no files.
+func listFiles(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return files, nil
+	}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = b
+		return nil
+	})
+	return files, err
+}
+
+// replaceDir replaces the contents of the target directory with the files of the source directory.
+func replaceDir(sourceDir string, targetDir string) error {
+	err := os.RemoveAll(targetDir)
+	if err != nil {
+		return err
+	}
+	files, err := listFiles(sourceDir)
+	if err != nil {
+		return err
+	}
+	for name, content := range files {
+		path := filepath.Join(targetDir, filepath.FromSlash(name))
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			return err
+		}
+		err = ioutil.WriteFile(path, content, 0644)
+		if err != nil {
+			return err
+		}
+	}
+	return os.MkdirAll(targetDir, 0755)
+}
@@ -0,0 +1,177 @@
+package templatetest
+
+import (
+	"github.com/Flaque/filet"
+	"github.com/bmuschko/letsgopher/testhelper"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const harnessManifest = `version: "1.0.0"
+goModule: true
+parameters:
+  - name: "module"
+    type: "modulePath"
+    defaultValue: "github.com/example/placeholder"
+  - name: "appName"
+    type: "string"
+    defaultValue: "hello"
+`
+
+func TestRunPassingCase(t *testing.T) {
+	dir := writeTemplate(t, map[string]string{
+		"tests/default/answers.yaml":                "module: \"github.com/acme/orders\"\nappName: \"orders\"\n",
+		"tests/default/expected/go.mod":             "module github.com/acme/orders\n",
+		"tests/default/expected/cmd/orders/main.go": "package main\n\nimport \"github.com/acme/orders/internal\"\n",
+		"tests/defaults/expected/go.mod":            "module github.com/example/placeholder\n",
+		"tests/defaults/expected/cmd/hello/main.go": "package main\n\nimport \"github.com/example/placeholder/internal\"\n",
+	})
+	defer filet.CleanUp(t)
+
+	results, err := (&Harness{}).Run(dir)
+
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+	for _, r := range results {
+		assert.Nil(t, r.Err, r.Case.Name)
+		assert.Empty(t, r.Mismatches, r.Case.Name)
+		assert.True(t, r.Passed(), r.Case.Name)
+	}
+	assert.Equal(t, "default", results[0].Case.Name)
+	assert.Equal(t, "defaults", results[1].Case.Name)
+}
+
+func TestRunFailingCase(t *testing.T) {
+	dir := writeTemplate(t, map[string]string{
+		"tests/default/answers.yaml":                "appName: \"orders\"\n",
+		"tests/default/expected/go.mod":             "module github.com/example/placeholder\n",
+		"tests/default/expected/cmd/main.go":        "package main\n",
+		"tests/default/expected/cmd/orders/main.go": "package orders\n",
+	})
+	defer filet.CleanUp(t)
+
+	results, err := (&Harness{}).Run(dir)
+
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+	assert.False(t, results[0].Passed())
+	assert.Equal(t, []*Mismatch{
+		{File: "cmd/main.go", Kind: MissingFile, Diff: "--- expected/cmd/main.go\n+++ /dev/null\n@@ -1 +0,0 @@\n-package main\n"},
+		{File: "cmd/orders/main.go", Kind: ContentMismatch, Diff: "--- expected/cmd/orders/main.go\n+++ generated/cmd/orders/main.go\n@@ -1 +1,3 @@\n-package orders\n+package main\n+\n+import \"github.com/example/placeholder/internal\"\n"},
+	}, results[0].Mismatches)
+}
+
+func TestRunReportsUnexpectedFilesAndInvalidAnswers(t *testing.T) {
+	dir := writeTemplate(t, map[string]string{
+		"tests/invalid/answers.yaml": "module: \"not a module\"\n",
+		"tests/missing/answers.yaml": "appName: \"orders\"\n",
+	})
+	defer filet.CleanUp(t)
+
+	results, err := (&Harness{}).Run(dir)
+
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+	assert.NotNil(t, results[0].Err)
+	assert.Contains(t, results[0].Err.Error(), "provided value for parameter \"module\" is invalid")
+	assert.False(t, results[0].Passed())
+	assert.Equal(t, []string{"cmd/orders/main.go", "go.mod"}, mismatchFiles(results[1].Mismatches))
+	assert.Equal(t, UnexpectedFile, results[1].Mismatches[0].Kind)
+	assert.Equal(t, "--- /dev/null\n+++ generated/go.mod\n@@ -0,0 +1 @@\n+module github.com/example/placeholder\n", results[1].Mismatches[1].Diff)
+}
+
+func TestRunUpdatesExpectedFiles(t *testing.T) {
+	dir := writeTemplate(t, map[string]string{
+		"tests/default/answers.yaml":      "appName: \"orders\"\n",
+		"tests/default/expected/stale.go": "package stale\n",
+	})
+	defer filet.CleanUp(t)
+
+	results, err := (&Harness{Update: true}).Run(dir)
+
+	assert.Nil(t, err)
+	assert.True(t, results[0].Updated)
+	assert.True(t, results[0].Passed())
+	expectedDir := filepath.Join(dir, "tests", "default", "expected")
+	testhelper.FileNotExists(t, filepath.Join(expectedDir, "stale.go"))
+	assert.Equal(t, "module github.com/example/placeholder\n", testhelper.ReadFile(t, filepath.Join(expectedDir, "go.mod")))
+
+	results, err = (&Harness{}).Run(dir)
+	assert.Nil(t, err)
+	assert.True(t, results[0].Passed())
+	assert.False(t, results[0].Updated)
+}
+
+func TestRunProvidesImplicitModuleParamsFromAnswers(t *testing.T) {
+	dir := writeTemplate(t, map[string]string{
+		"handler.go.tmpl":                              "package {{ .appName }} // import \"{{ .importPath }}\"\n",
+		"tests/default/answers.yaml":                   "appName: \"orders\"\nimportPath: \"github.com/acme/shop/orders\"\n",
+		"tests/default/expected/go.mod":                "module github.com/example/placeholder\n",
+		"tests/default/expected/cmd/orders/main.go":    "package main\n\nimport \"github.com/example/placeholder/internal\"\n",
+		"tests/default/expected/handler.go.tmpl":       "package orders // import \"github.com/acme/shop/orders\"\n",
+		"tests/overridden/answers.yaml":                "appName: \"orders\"\nimportPath: \"github.com/acme/shop/orders\"\n",
+		"tests/overridden/expected/go.mod":             "module github.com/example/placeholder\n",
+		"tests/overridden/expected/cmd/orders/main.go": "package main\n\nimport \"github.com/example/placeholder/internal\"\n",
+		"tests/overridden/expected/handler.go.tmpl":    "package orders // import \"github.com/acme/shop/billing\"\n",
+	})
+	defer filet.CleanUp(t)
+	testhelper.WriteFile(t, filepath.Join(dir, "manifest.yaml"), "version: \"1.0.0\"\nparameters:\n  - name: \"appName\"\n    type: \"string\"\n", 0644)
+
+	results, err := (&Harness{}).Run(dir)
+
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+	assert.Nil(t, results[0].Err)
+	assert.Empty(t, results[0].Mismatches)
+	assert.NotEmpty(t, results[1].Mismatches)
+
+	c := &Case{Name: "overridden", Dir: filepath.Join(dir, "tests", "overridden")}
+	result, err := (&Harness{Implicit: map[string]interface{}{"importPath": "github.com/acme/shop/billing"}}).RunCase(dir, c)
+
+	assert.Nil(t, err)
+	assert.Nil(t, result.Err)
+	assert.Empty(t, result.Mismatches)
+}
+
+func TestLoadCasesWithoutTestCases(t *testing.T) {
+	dir := writeTemplate(t, map[string]string{})
+	defer filet.CleanUp(t)
+
+	_, err := LoadCases(dir)
+	assert.NotNil(t, err)
+	assert.Equal(t, "template \""+dir+"\" needs to contain a tests directory with test cases", err.Error())
+
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, TestsDir), 0755))
+	_, err = LoadCases(dir)
+	assert.NotNil(t, err)
+	assert.Equal(t, "template \""+dir+"\" does not define any test cases in its tests directory", err.Error())
+}
+
+func TestHarnessAsGoTest(t *testing.T) {
+	dir := writeTemplate(t, map[string]string{
+		"tests/default/expected/go.mod":            "module github.com/example/placeholder\n",
+		"tests/default/expected/cmd/hello/main.go": "package main\n\nimport \"github.com/example/placeholder/internal\"\n",
+	})
+	defer filet.CleanUp(t)
+
+	(&Harness{}).Test(t, dir)
+}
+
+func writeTemplate(t *testing.T, files map[string]string) string {
+	dir := filet.TmpDir(t, "")
+	files["manifest.yaml"] = harnessManifest
+	files["go.mod"] = "module github.com/example/placeholder\n"
+	files["cmd/{{ .appName }}/main.go"] = "package main\n\nimport \"github.com/example/placeholder/internal\"\n"
+	testhelper.WriteFiles(t, dir, files)
+	return dir
+}
+
+func mismatchFiles(mismatches []*Mismatch) []string {
+	files := []string{}
+	for _, m := range mismatches {
+		files = append(files, m.File)
+	}
+	return files
+}
@@ -0,0 +1,41 @@
+package templatetest
+
+import (
+	"testing"
+)
+
+// Test runs every test case of a template directory as subtest of a Go test.
+// The template is packaged once for all test cases. Mismatching files are reported with their diff. Use it from a test of the template repository, e.g.
+//
+//	func TestTemplate(t *testing.T) {
+//		(&templatetest.Harness{Update: *update}).Test(t, ".")
+//	}
+func (h *Harness) Test(t *testing.T, templateDir string) {
+	t.Helper()
+	cases, err := LoadCases(templateDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, archiveFile, cleanUp, err := prepare(templateDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanUp()
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			result := h.runCase(archiveFile, m, c)
+			if result.Err != nil {
+				t.Fatal(result.Err)
+			}
+			if result.Updated {
+				t.Logf("updated the expected files of test case %q", c.Name)
+				return
+			}
+			for _, m := range result.Mismatches {
+				t.Errorf("%s %s:\n%s", m.File, m.Kind, m.Diff)
+			}
+		})
+	}
+}
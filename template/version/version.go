@@ -0,0 +1,57 @@
+package version
+
+import (
+	"github.com/blang/semver"
+	"strings"
+)
+
+const comparatorChars = "<>=!"
+
+// Parse parses a semantic version tolerating a "v" prefix as well as missing minor and patch versions.
+func Parse(s string) (semver.Version, error) {
+	return semver.ParseTolerant(s)
+}
+
+// ParseRange parses a version range like ">=1.21 <2.0.0" or "1.2.x || >=2.1".
+// In contrast to semver.ParseRange, versions may omit minor and patch versions and use a "v" prefix.
+func ParseRange(s string) (semver.Range, error) {
+	tokens := strings.Fields(s)
+	for i, t := range tokens {
+		if t == "||" {
+			continue
+		}
+		v := strings.TrimLeft(t, comparatorChars)
+		op := t[:len(t)-len(v)]
+		tokens[i] = op + complete(strings.TrimPrefix(v, "v"))
+	}
+	return semver.ParseRange(strings.Join(tokens, " "))
+}
+
+// Satisfies determines whether a version lies within a version range.
+func Satisfies(v string, r string) (bool, error) {
+	parsedVersion, err := Parse(v)
+	if err != nil {
+		return false, err
+	}
+	parsedRange, err := ParseRange(r)
+	if err != nil {
+		return false, err
+	}
+	return parsedRange(parsedVersion), nil
+}
+
+func complete(v string) string {
+	core := v
+	suffix := ""
+	if i := strings.IndexAny(v, "-+"); i != -1 {
+		core, suffix = v[:i], v[i:]
+	}
+	parts := strings.Split(core, ".")
+	for len(parts) < 3 {
+		if parts[len(parts)-1] == "x" || parts[len(parts)-1] == "X" || parts[len(parts)-1] == "*" {
+			break
+		}
+		parts = append(parts, "0")
+	}
+	return strings.Join(parts, ".") + suffix
+}
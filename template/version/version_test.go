@@ -0,0 +1,40 @@
+package version
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSatisfies(t *testing.T) {
+	ranges := []struct {
+		version  string
+		r        string
+		expected bool
+	}{
+		{"1.21.3", ">=1.21", true},
+		{"1.20", ">=1.21", false},
+		{"v1.2.3", ">=1.0.0 <2.0.0", true},
+		{"2.0.0", ">=1.0.0 <2", false},
+		{"1.2.7", "1.2.x", true},
+		{"1.3.0", "1.2.x", false},
+		{"2.1.0", "<1.0 || >=2.1", true},
+		{"1.0.0-beta.1", ">=1", false},
+		{"1.5.0", "!=1.5", false},
+	}
+	for _, r := range ranges {
+		t.Run(r.version+" "+r.r, func(t *testing.T) {
+			ok, err := Satisfies(r.version, r.r)
+
+			assert.Nil(t, err)
+			assert.Equal(t, r.expected, ok)
+		})
+	}
+}
+
+func TestSatisfiesForInvalidInput(t *testing.T) {
+	_, err := Satisfies("latest", ">=1.0.0")
+	assert.NotNil(t, err)
+
+	_, err = Satisfies("1.0.0", ">=one")
+	assert.NotNil(t, err)
+}
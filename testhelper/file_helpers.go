@@ -4,6 +4,7 @@ import (
 	"archive/zip"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -59,3 +60,16 @@ func WriteFile(t *testing.T, file string, content string, perm os.FileMode) {
 		t.Fatalf("Failed to write file %s. Reason: %s", file, err)
 	}
 }
+
+// WriteFiles writes the textual content of files keyed by their slash-separated path relative to a directory.
+// Missing parent directories are created.
+func WriteFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatalf("Failed to create directory %s. Reason: %s", filepath.Dir(path), err)
+		}
+		WriteFile(t, path, content, 0644)
+	}
+}